@@ -0,0 +1,259 @@
+package ai
+
+import "math"
+
+// State é o estado atual da máquina de estados de um inimigo.
+type State uint8
+
+const (
+	Idle   State = iota // parado em casa
+	Patrol              // andando pela rota de patrulha
+	Chase               // perseguindo o alvo
+	Attack              // perto o bastante para bater
+	Return              // voltando para casa depois de perder o alvo
+	Flee                // fugindo com pouca vida
+)
+
+func (s State) String() string {
+	switch s {
+	case Idle:
+		return "idle"
+	case Patrol:
+		return "patrol"
+	case Chase:
+		return "chase"
+	case Attack:
+		return "attack"
+	case Return:
+		return "return"
+	case Flee:
+		return "flee"
+	}
+	return "unknown"
+}
+
+type Point struct {
+	X, Y float64
+}
+
+// Params são os parâmetros de comportamento, vindos da definição do
+// inimigo e das propriedades do objeto enemy_spawn no Tiled. Distâncias
+// em pixels, velocidades em pixels por tick.
+type Params struct {
+	Chases            bool    `json:"follows_player"` // se false o inimigo nunca persegue, só bate em quem chega perto
+	AggroRadius       float64 `json:"aggro_radius"`   // distância em que começa a perseguir
+	AttackRange       float64 `json:"attack_range"`   // distância em que para e ataca
+	LeashRadius       float64 `json:"leash_radius"`   // quão longe de casa aceita ir
+	Speed             float64 `json:"speed"`
+	FleeHealthPercent int     `json:"flee_health_percent"` // foge com a vida nessa % ou menos (0 desliga)
+	IdleTicks         int     `json:"idle_ticks"`          // quanto espera parado antes de patrulhar
+	MemoryTicks       int     `json:"memory_ticks"`        // quanto continua perseguindo sem ver o alvo
+
+	Patrol     []Point `json:"-"` // rota de patrulha (vazia = fica parado)
+	PatrolLoop bool    `json:"-"` // true: volta ao início (polígono); false: vai e volta
+}
+
+func DefaultParams() Params {
+	return Params{
+		Chases:            true,
+		AggroRadius:       80,
+		AttackRange:       14,
+		LeashRadius:       160,
+		Speed:             1,
+		FleeHealthPercent: 25,
+		IdleTicks:         60,
+		MemoryTicks:       120,
+	}
+}
+
+// Perception é o que o inimigo sabe do mundo neste tick.
+type Perception struct {
+	Self          Point
+	Target        Point
+	TargetVisible bool // linha de visão livre até o alvo
+	Health        int
+	MaxHealth     int
+}
+
+// Navigator sugere o próximo ponto do caminho até um destino, desviando
+// das paredes (ex: pathfinding.Follower). ok é false se não há caminho.
+type Navigator interface {
+	NextWaypoint(fromX, fromY, toX, toY float64) (x, y float64, ok bool)
+	Reset()
+}
+
+// Brain é a máquina de estados de um inimigo.
+type Brain struct {
+	Params
+	Home Point
+	// Nav é opcional; sem ele o inimigo anda em linha reta.
+	Nav Navigator
+
+	state       State
+	stateTicks  int // ticks desde a última troca de estado
+	lostTicks   int // ticks desde que perdeu o alvo de vista
+	patrolIndex int
+	patrolStep  int // +1 ou -1 ao ir e voltar numa polilinha
+}
+
+func NewBrain(home Point, params Params) *Brain {
+	return &Brain{
+		Params:     params,
+		Home:       home,
+		state:      Idle,
+		patrolStep: 1,
+	}
+}
+
+func (b *Brain) State() State {
+	return b.state
+}
+
+// WantsAttack diz se o inimigo está em posição de atacar.
+func (b *Brain) WantsAttack() bool {
+	return b.state == Attack
+}
+
+func (b *Brain) setState(s State) {
+	if b.state != s {
+		b.state = s
+		b.stateTicks = 0
+		if b.Nav != nil {
+			b.Nav.Reset()
+		}
+	}
+}
+
+// Update decide o próximo estado e retorna a velocidade desejada.
+func (b *Brain) Update(p Perception) (float64, float64) {
+	b.stateTicks++
+	b.transition(p)
+
+	switch b.state {
+	case Patrol:
+		return b.patrol(p.Self)
+	case Chase:
+		return b.moveTowards(p.Self, b.steer(p.Self, p.Target), b.Speed)
+	case Return:
+		return b.moveTowards(p.Self, b.steer(p.Self, b.Home), b.Speed)
+	case Flee:
+		dx, dy := b.moveTowards(p.Self, p.Target, b.Speed)
+		return -dx, -dy
+	}
+	return 0, 0
+}
+
+func (b *Brain) transition(p Perception) {
+	toTarget := distance(p.Self, p.Target)
+	fromHome := distance(p.Self, b.Home)
+	notices := b.Chases && p.TargetVisible && toTarget <= b.AggroRadius
+	if notices {
+		b.lostTicks = 0
+	} else {
+		b.lostTicks++
+	}
+
+	if b.lowHealth(p) && toTarget <= b.AggroRadius {
+		b.setState(Flee)
+		return
+	}
+
+	switch b.state {
+	case Idle, Patrol:
+		if notices {
+			b.setState(Chase)
+		} else if !b.Chases && toTarget <= b.AttackRange {
+			b.setState(Attack)
+		} else if b.state == Idle && len(b.Patrol) > 0 && b.stateTicks >= b.IdleTicks {
+			b.setState(Patrol)
+		}
+	case Chase:
+		// Sem ver o alvo continua procurando por um tempo (dando a volta
+		// em paredes, se tiver Nav) antes de desistir
+		switch {
+		case fromHome > b.LeashRadius || b.lostTicks > b.MemoryTicks:
+			b.setState(Return)
+		case toTarget <= b.AttackRange:
+			b.setState(Attack)
+		}
+	case Attack:
+		// Quem não persegue volta ao que fazia antes
+		if toTarget > b.AttackRange && b.Chases {
+			b.setState(Chase)
+		} else if toTarget > b.AttackRange {
+			b.setState(Idle)
+		}
+	case Return:
+		if notices && fromHome <= b.LeashRadius/2 {
+			b.setState(Chase)
+		} else if fromHome <= b.Speed {
+			b.setState(Idle)
+		}
+	case Flee:
+		// Para de fugir quando estiver longe o bastante
+		if toTarget > b.AggroRadius*1.5 {
+			b.setState(Return)
+		}
+	}
+}
+
+func (b *Brain) lowHealth(p Perception) bool {
+	if b.FleeHealthPercent <= 0 || p.MaxHealth <= 0 {
+		return false
+	}
+	return p.Health*100 <= p.MaxHealth*b.FleeHealthPercent
+}
+
+// patrol anda até o ponto atual da rota e, ao chegar, escolhe o próximo.
+func (b *Brain) patrol(self Point) (float64, float64) {
+	target := b.Patrol[b.patrolIndex]
+	speed := b.Speed / 2 // patrulha sem pressa
+	if distance(self, target) <= speed {
+		b.nextWaypoint()
+		target = b.Patrol[b.patrolIndex]
+	}
+	return b.moveTowards(self, target, speed)
+}
+
+func (b *Brain) nextWaypoint() {
+	if len(b.Patrol) < 2 {
+		return
+	}
+	if b.PatrolLoop {
+		b.patrolIndex = (b.patrolIndex + 1) % len(b.Patrol)
+		return
+	}
+	next := b.patrolIndex + b.patrolStep
+	if next < 0 || next >= len(b.Patrol) {
+		b.patrolStep = -b.patrolStep
+		next = b.patrolIndex + b.patrolStep
+	}
+	b.patrolIndex = next
+}
+
+// steer troca o destino final pelo próximo ponto do caminho, se houver Nav.
+func (b *Brain) steer(from, to Point) Point {
+	if b.Nav == nil {
+		return to
+	}
+	if x, y, ok := b.Nav.NextWaypoint(from.X, from.Y, to.X, to.Y); ok {
+		return Point{X: x, Y: y}
+	}
+	return to
+}
+
+// moveTowards retorna uma velocidade de módulo speed na direção do alvo,
+// sem passar do alvo.
+func (b *Brain) moveTowards(from, to Point, speed float64) (float64, float64) {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	d := math.Sqrt(dx*dx + dy*dy)
+	if d < 0.5 {
+		return 0, 0
+	}
+	step := math.Min(speed, d)
+	return dx / d * step, dy / d * step
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
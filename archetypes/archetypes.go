@@ -0,0 +1,336 @@
+package archetypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"rpg-go/ai"
+	"rpg-go/animations"
+	"rpg-go/components"
+	"sort"
+	"strings"
+)
+
+// Kind diz qual tipo de entidade o jogo monta a partir da definição.
+type Kind string
+
+const (
+	KindEnemy  Kind = "enemy"  // tem combate e IA
+	KindDummy  Kind = "dummy"  // boneco de treino: só reage a golpes
+	KindPickup Kind = "pickup" // item coletável
+	KindProp   Kind = "prop"   // só um sprite parado
+	KindNPC    Kind = "npc"    // personagem com quem o jogador conversa
+
+	KindProjectile Kind = "projectile" // arremessado por alguém; anda até bater
+)
+
+// Archetype é a definição de um tipo de entidade, lida de um arquivo
+// JSON em assets/entities. Um tipo novo de objeto do Tiled pode ser
+// suportado só adicionando um arquivo desses.
+type Archetype struct {
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+	// SpawnTypes são os tipos de objeto do Tiled que criam esta entidade
+	// quando o objeto não escolhe outra pelo nome (propriedade "archetype"
+	// ou "enemy_type").
+	SpawnTypes []string `json:"spawn_types"`
+
+	Sprite     SpriteDef               `json:"sprite"`
+	Collider   *ColliderDef            `json:"collider,omitempty"`
+	Animations map[string]AnimationDef `json:"animations"`
+	Combat     *CombatDef              `json:"combat,omitempty"`
+	AI         *ai.Params              `json:"ai,omitempty"`
+	Pickup     *PickupDef              `json:"pickup,omitempty"`
+	Projectile *ProjectileDef          `json:"projectile,omitempty"`
+	Ranged     *RangedDef              `json:"ranged,omitempty"`
+	NPC        *NPCDef                 `json:"npc,omitempty"`
+	// Loot é a tabela de drops (de assets/loot) sorteada quando a entidade morre
+	Loot string `json:"loot,omitempty"`
+	// XP é a experiência que o jogador ganha ao matar a entidade
+	XP int `json:"xp,omitempty"`
+}
+
+type SpriteDef struct {
+	// Image é relativo ao arquivo de definição, como no Tiled.
+	Image       string `json:"image"`
+	FrameWidth  int    `json:"frame_width"`
+	FrameHeight int    `json:"frame_height"`
+	Columns     int    `json:"columns"` // frames por linha da imagem
+	Frame       int    `json:"frame"`   // frame mostrado quando não há animação
+}
+
+// ColliderDef é o tamanho da entidade para colisões. Sem ele vale o
+// tamanho do frame.
+type ColliderDef struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// AnimationDef espelha os parâmetros de animations.NewAnimation.
+type AnimationDef struct {
+	First int     `json:"first"`
+	Last  int     `json:"last"`
+	Step  int     `json:"step"`
+	Speed float32 `json:"speed"`
+}
+
+type CombatDef struct {
+	Health         int `json:"health"`
+	AttackPower    int `json:"attack_power"`
+	AttackCooldown int `json:"attack_cooldown"` // em ticks
+
+	DamageType     components.DamageType `json:"damage_type"`
+	CritChance     float64               `json:"crit_chance"`
+	CritMultiplier float64               `json:"crit_multiplier"`
+
+	Armor       int                               `json:"armor"`
+	Resistances map[components.DamageType]float64 `json:"resistances"`
+
+	// OnHit são os efeitos de status que o ataque normal aplica
+	OnHit []components.EffectKind `json:"on_hit"`
+}
+
+// NewCombat cria o componente de combate de uma instância.
+func (c *CombatDef) NewCombat() *components.EnemyCombat {
+	combat := components.NewEnemieCombat(c.Health, c.AttackPower, c.AttackCooldown)
+	damageType := c.DamageType
+	if damageType == "" {
+		damageType = components.DamagePhysical
+	}
+	combat.SetAttackStyle(damageType, c.CritChance, c.CritMultiplier)
+	combat.SetDefense(components.Defense{Armor: c.Armor, Resistances: c.Resistances})
+	return combat
+}
+
+type PickupDef struct {
+	Heal    int                     `json:"heal"`
+	Effects []components.EffectKind `json:"effects"` // aplicados ao coletar
+
+	// Item vai para a bolsa do jogador em vez de ser usado na hora
+	Item  string `json:"item"`
+	Count int    `json:"count"` // quantos itens (padrão 1)
+}
+
+type ProjectileDef struct {
+	Damage         int                   `json:"damage"`
+	DamageType     components.DamageType `json:"damage_type"`
+	CritChance     float64               `json:"crit_chance"`
+	CritMultiplier float64               `json:"crit_multiplier"`
+
+	OnHit []components.EffectKind `json:"on_hit"`
+
+	Speed    float64 `json:"speed"`     // pixels por tick
+	LifeSpan int     `json:"life_span"` // em ticks
+	Spin     float64 `json:"spin"`      // radianos por tick
+}
+
+// DamageInfo é o dano do projétil causado por source.
+func (p *ProjectileDef) DamageInfo(source string) components.DamageInfo {
+	return components.DamageInfo{
+		Source:         source,
+		Type:           p.DamageType,
+		Amount:         p.Damage,
+		CritChance:     p.CritChance,
+		CritMultiplier: p.CritMultiplier,
+	}
+}
+
+// Effects converte nomes de efeitos nas definições de
+// components.StandardEffects. Nomes desconhecidos são ignorados (Add já
+// recusa arquétipos com eles).
+func Effects(kinds []components.EffectKind) []components.EffectDef {
+	defs := make([]components.EffectDef, 0, len(kinds))
+	for _, kind := range kinds {
+		if def, ok := components.StandardEffects[kind]; ok {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// RangedDef dá a um inimigo um ataque à distância: quando a IA está em
+// posição de atacar, ele arremessa o arquétipo Projectile no jogador.
+type RangedDef struct {
+	Projectile string `json:"projectile"`
+	Cooldown   int    `json:"cooldown"` // em ticks
+}
+
+// NPCDef é a conversa de um NPC.
+type NPCDef struct {
+	Name     string `json:"name"`     // mostrado na caixa de texto
+	Dialogue string `json:"dialogue"` // árvore de assets/dialogue
+}
+
+// ColliderSize é o tamanho do colisor, ou do frame se não houver um.
+func (a *Archetype) ColliderSize() (int, int) {
+	if a.Collider != nil {
+		return a.Collider.Width, a.Collider.Height
+	}
+	return a.Sprite.FrameWidth, a.Sprite.FrameHeight
+}
+
+// NewAnimations cria animações novas (com estado próprio) para uma instância.
+func (a *Archetype) NewAnimations() map[string]*animations.Animation {
+	result := make(map[string]*animations.Animation, len(a.Animations))
+	for name, def := range a.Animations {
+		result[name] = animations.NewAnimation(def.First, def.Last, def.Step, def.Speed)
+	}
+	return result
+}
+
+// AIParams retorna uma cópia dos parâmetros de IA (ou os padrões).
+func (a *Archetype) AIParams() ai.Params {
+	if a.AI == nil {
+		return ai.DefaultParams()
+	}
+	return *a.AI
+}
+
+// Registry guarda todas as definições carregadas.
+type Registry struct {
+	byName       map[string]*Archetype
+	byObjectType map[string]*Archetype
+}
+
+// Load lê todos os arquivos .json de um diretório.
+func Load(dir string) (*Registry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	r := &Registry{
+		byName:       make(map[string]*Archetype),
+		byObjectType: make(map[string]*Archetype),
+	}
+	for _, path := range paths {
+		def, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.Add(def); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+func loadFile(path string) (*Archetype, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler a definição %s: %w", path, err)
+	}
+
+	// Os parâmetros de IA partem dos padrões: o arquivo só precisa
+	// listar o que muda.
+	var raw struct {
+		AI json.RawMessage `json:"ai"`
+	}
+	def := &Archetype{}
+	if err := json.Unmarshal(contents, def); err != nil {
+		return nil, fmt.Errorf("falha ao decodificar a definição %s: %w", path, err)
+	}
+	if err := json.Unmarshal(contents, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw.AI) > 0 {
+		params := ai.DefaultParams()
+		if err := json.Unmarshal(raw.AI, &params); err != nil {
+			return nil, fmt.Errorf("falha ao decodificar a IA de %s: %w", path, err)
+		}
+		def.AI = &params
+	}
+
+	if def.Name == "" {
+		def.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if def.Sprite.Image != "" {
+		def.Sprite.Image = filepath.Join(filepath.Dir(path), def.Sprite.Image)
+	}
+	return def, nil
+}
+
+// Add registra uma definição.
+func (r *Registry) Add(def *Archetype) error {
+	switch def.Kind {
+	case KindEnemy, KindDummy, KindPickup, KindProp, KindProjectile, KindNPC:
+	default:
+		return fmt.Errorf("arquétipo %q: tipo desconhecido %q", def.Name, def.Kind)
+	}
+	if def.Kind == KindEnemy && def.Combat == nil {
+		return fmt.Errorf("arquétipo %q: inimigos precisam de \"combat\"", def.Name)
+	}
+	if def.Kind == KindNPC && def.NPC == nil {
+		return fmt.Errorf("arquétipo %q: NPCs precisam de \"npc\"", def.Name)
+	}
+	if def.Kind == KindProjectile && def.Projectile == nil {
+		return fmt.Errorf("arquétipo %q: projéteis precisam de \"projectile\"", def.Name)
+	}
+	for name, anim := range def.Animations {
+		if anim.Last < anim.First {
+			return fmt.Errorf("arquétipo %q: animação %q termina (%d) antes de começar (%d)", def.Name, name, anim.Last, anim.First)
+		}
+	}
+	for _, kind := range def.effectKinds() {
+		if _, ok := components.StandardEffects[kind]; !ok {
+			return fmt.Errorf("arquétipo %q: efeito desconhecido %q", def.Name, kind)
+		}
+	}
+	if _, exists := r.byName[def.Name]; exists {
+		return fmt.Errorf("arquétipo %q definido duas vezes", def.Name)
+	}
+
+	r.byName[def.Name] = def
+	for _, objectType := range def.SpawnTypes {
+		if other, exists := r.byObjectType[objectType]; exists {
+			return fmt.Errorf("tipo de objeto %q já é criado por %q", objectType, other.Name)
+		}
+		r.byObjectType[objectType] = def
+	}
+	return nil
+}
+
+// effectKinds são todos os efeitos citados pela definição.
+func (a *Archetype) effectKinds() []components.EffectKind {
+	var kinds []components.EffectKind
+	if a.Combat != nil {
+		kinds = append(kinds, a.Combat.OnHit...)
+	}
+	if a.Projectile != nil {
+		kinds = append(kinds, a.Projectile.OnHit...)
+	}
+	if a.Pickup != nil {
+		kinds = append(kinds, a.Pickup.Effects...)
+	}
+	return kinds
+}
+
+// Get busca uma definição pelo nome.
+func (r *Registry) Get(name string) (*Archetype, bool) {
+	def, ok := r.byName[name]
+	return def, ok
+}
+
+// ForObject escolhe a definição de um objeto do Tiled: pelo nome pedido
+// no objeto, se houver, senão pelo tipo do objeto.
+func (r *Registry) ForObject(objectType, requestedName string) (*Archetype, bool) {
+	if requestedName != "" {
+		if def, ok := r.byName[requestedName]; ok {
+			return def, true
+		}
+	}
+	def, ok := r.byObjectType[objectType]
+	return def, ok
+}
+
+// All retorna as definições ordenadas por nome.
+func (r *Registry) All() []*Archetype {
+	all := make([]*Archetype, 0, len(r.byName))
+	for _, def := range r.byName {
+		all = append(all, def)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
@@ -16,9 +16,15 @@ func (c *Camera) FollowTarget(targetX, targetY float64, screenW, screenH float64
 }
 
 func (c *Camera) Constrain(tileMapWidthPixels, tileMapHeightPixels, screenWidth, screenHeight float64) {
-	c.X = math.Min(c.X, 0.0)
-	c.Y = math.Min(c.Y, 0.0)
+	c.ConstrainRect(0, 0, tileMapWidthPixels, tileMapHeightPixels, screenWidth, screenHeight)
+}
+
+// ConstrainRect mantém a câmera dentro de um mapa que começa em (minX,
+// minY), que pode ser negativo em mapas infinitos.
+func (c *Camera) ConstrainRect(minX, minY, maxX, maxY, screenWidth, screenHeight float64) {
+	c.X = math.Min(c.X, -minX)
+	c.Y = math.Min(c.Y, -minY)
 
-	c.X = math.Max(c.X, screenWidth-tileMapWidthPixels)
-	c.Y = math.Max(c.Y, screenHeight-tileMapHeightPixels)
+	c.X = math.Max(c.X, screenWidth-maxX)
+	c.Y = math.Max(c.Y, screenHeight-maxY)
 }
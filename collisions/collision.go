@@ -1,11 +1,15 @@
 package collisions
 
-import "image"
+import (
+	"image"
+	"math"
+)
 
 const CellSize = 64
 
 type Grid struct {
 	cols, rows int
+	bounds     image.Rectangle // área do mapa em pixels
 	cells      [][]_Cells
 }
 
@@ -14,8 +18,14 @@ type _Cells struct {
 }
 
 func NewGrid(width, height int) *Grid {
-	cols := (width + CellSize - 1) / CellSize
-	rows := (height + CellSize - 1) / CellSize
+	return NewGridRect(image.Rect(0, 0, width, height))
+}
+
+// NewGridRect cria a grade para uma área que não começa em (0, 0), como
+// a de um mapa infinito.
+func NewGridRect(bounds image.Rectangle) *Grid {
+	cols := (bounds.Dx() + CellSize - 1) / CellSize
+	rows := (bounds.Dy() + CellSize - 1) / CellSize
 
 	cells := make([][]_Cells, rows)
 
@@ -27,17 +37,50 @@ func NewGrid(width, height int) *Grid {
 	}
 
 	return &Grid{
-		cols:  cols,
-		rows:  rows,
-		cells: cells,
+		cols:   cols,
+		rows:   rows,
+		bounds: bounds,
+		cells:  cells,
+	}
+}
+
+// Size retorna o tamanho em pixels da área coberta pela grade.
+func (g *Grid) Size() (int, int) {
+	return g.bounds.Dx(), g.bounds.Dy()
+}
+
+// Bounds retorna a área coberta pela grade, em pixels do mapa.
+func (g *Grid) Bounds() image.Rectangle {
+	return g.bounds
+}
+
+// cell retorna a coluna e a linha da célula que contém o ponto. Fora da
+// grade os índices podem ser negativos.
+func (g *Grid) cell(x, y int) (int, int) {
+	return floorDiv(x-g.bounds.Min.X, CellSize), floorDiv(y-g.bounds.Min.Y, CellSize)
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
 	}
+	return q
+}
+
+// Overlaps diz se algum colisor encosta na área.
+func (g *Grid) Overlaps(bounds image.Rectangle) bool {
+	for _, collider := range g.GetNearbyColliders(bounds) {
+		if collider.Overlaps(bounds) {
+			return true
+		}
+	}
+	return false
 }
 
 func (g *Grid) Insert(collider *image.Rectangle) {
-	minX := collider.Min.X / CellSize
-	maxX := (collider.Max.X - 1) / CellSize
-	minY := collider.Min.Y / CellSize
-	maxY := (collider.Max.Y - 1) / CellSize
+	minX, minY := g.cell(collider.Min.X, collider.Min.Y)
+	maxX, maxY := g.cell(collider.Max.X-1, collider.Max.Y-1)
 
 	for y := minY; y <= maxY; y++ {
 		for x := minX; x <= maxX; x++ {
@@ -52,10 +95,8 @@ func (g *Grid) Insert(collider *image.Rectangle) {
 func (g *Grid) GetNearbyColliders(bounds image.Rectangle) []*image.Rectangle {
 	nearby := make(map[*image.Rectangle]struct{}) // Usamos um map para evitar duplicatas
 
-	minX := bounds.Min.X / CellSize
-	maxX := (bounds.Max.X - 1) / CellSize
-	minY := bounds.Min.Y / CellSize
-	maxY := (bounds.Max.Y - 1) / CellSize
+	minX, minY := g.cell(bounds.Min.X, bounds.Min.Y)
+	maxX, maxY := g.cell(bounds.Max.X-1, bounds.Max.Y-1)
 
 	for y := minY; y <= maxY; y++ {
 		for x := minX; x <= maxX; x++ {
@@ -74,3 +115,25 @@ func (g *Grid) GetNearbyColliders(bounds image.Rectangle) []*image.Rectangle {
 	}
 	return result
 }
+
+// LineOfSight diz se o segmento entre a e b não atravessa nenhum colisor.
+// O segmento é amostrado a cada poucos pixels, o que basta para os
+// colisores do tamanho de tiles que usamos.
+func (g *Grid) LineOfSight(a, b image.Point) bool {
+	const step = 4
+
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	length := math.Sqrt(dx*dx + dy*dy)
+	steps := int(length/step) + 1
+
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		p := image.Pt(a.X+int(dx*t), a.Y+int(dy*t))
+		for _, collider := range g.GetNearbyColliders(image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 1))}) {
+			if p.In(*collider) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -1,5 +1,7 @@
 package components
 
+import "math/rand/v2"
+
 type Combat interface {
 	Health() int
 	AttackPower() int
@@ -8,19 +10,65 @@ type Combat interface {
 	Update()
 	Damage(amount int)
 	MaxHealth() int
+
+	// AttackDamage é o dano de um ataque normal, antes das defesas do alvo.
+	AttackDamage() DamageInfo
+	Defense() Defense
+
+	// Hit aplica um golpe com empurrão, invulnerabilidade e atordoamento.
+	Hit(impact Impact) (DamageResult, bool)
+	Invulnerable() bool
+	Stunned() bool
+	Knockback() (float64, float64)
+
+	// Efeitos de status (veneno, lentidão...) ativos
+	ApplyEffect(def EffectDef) bool
+	Effects() []*Effect
+	SpeedMultiplier() float64
+
+	// Flashing diz se o sprite deve piscar (sumir) neste tick, enquanto
+	// está invulnerável.
+	Flashing() bool
+}
+
+// Impact é tudo que um golpe faz com quem o recebe.
+type Impact struct {
+	Damage DamageInfo
+	// Empurrão em pixels por tick; perde força a cada Update
+	KnockbackX, KnockbackY float64
+	// Ticks sem tomar outro golpe
+	InvulnerableTicks int
+	// Ticks sem poder agir (andar, atacar)
+	StunTicks int
+	// Efeitos aplicados se o golpe acertar
+	Effects []EffectDef
+	// Rand sorteia o crítico; sem ele o golpe nunca é crítico
+	Rand *rand.Rand
 }
 
 type BasicCombat struct {
 	health      int
 	attackPower int
 	attacking   bool
-	maxHeath    int
+	maxHealth   int
+
+	// Como ataca e como se defende
+	damageType     DamageType
+	critChance     float64
+	critMultiplier float64
+	defense        Defense
+
+	invulnerableTicks      int     // ticks sem tomar dano depois de um golpe
+	stunTicks              int     // ticks sem poder agir depois de um golpe
+	knockbackX, knockbackY float64 // empurrão atual, em pixels por tick
+
+	effects Effects
 }
 
 func (b *BasicCombat) Heal(i int) {
 	b.health += i
-	// if b.health >= b.maxHeath {
-	// 	b.health = b.maxHeath
+	// if b.health >= b.maxHealth {
+	// 	b.health = b.maxHealth
 	// }
 }
 
@@ -29,18 +77,34 @@ func NewBasicCombat(health, attackPower int) *BasicCombat {
 		health:      health,
 		attackPower: attackPower,
 		attacking:   false,
-		maxHeath:    health,
+		maxHealth:   health,
+		damageType:  DamagePhysical,
 	}
 }
 
 func (b *BasicCombat) MaxHealth() int {
-	return b.maxHeath
+	return b.maxHealth
+}
+
+// SetHealth define a vida atual diretamente (ex: ao carregar um save).
+func (b *BasicCombat) SetHealth(health int) {
+	b.health = health
+}
+
+// SetMaxHealth define a vida máxima.
+func (b *BasicCombat) SetMaxHealth(maxHealth int) {
+	b.maxHealth = maxHealth
 }
 
 func (b *BasicCombat) Damage(amount int) {
 	b.health -= amount
 }
 
+// SetAttackPower define o dano base dos ataques (ex: ao subir de nível).
+func (b *BasicCombat) SetAttackPower(attackPower int) {
+	b.attackPower = attackPower
+}
+
 // AttackPower implements Combat.
 func (b *BasicCombat) AttackPower() int {
 	return b.attackPower
@@ -60,7 +124,127 @@ func (b *BasicCombat) Attack() bool {
 }
 
 func (b *BasicCombat) Update() {
+	if b.invulnerableTicks > 0 {
+		b.invulnerableTicks--
+	}
+	if b.stunTicks > 0 {
+		b.stunTicks--
+	}
+
+	// Dano e cura dos efeitos; o dano passa pelas resistências mas não
+	// tem crítico nem é bloqueado pela invulnerabilidade
+	damage, heal := b.effects.Update()
+	for damageType, amount := range damage {
+		result := ResolveDamage(DamageInfo{Source: "effect", Type: damageType, Amount: amount}, b.defense, 1)
+		b.Damage(result.Amount)
+	}
+	if heal > 0 && b.health < b.maxHealth {
+		b.Heal(min(heal, b.maxHealth-b.health))
+	}
+
+	// O empurrão perde força a cada tick até parar
+	b.knockbackX *= knockbackFriction
+	b.knockbackY *= knockbackFriction
+	if b.knockbackX*b.knockbackX+b.knockbackY*b.knockbackY < 0.01 {
+		b.knockbackX, b.knockbackY = 0, 0
+	}
+}
+
+const knockbackFriction = 0.8
+
+// Hit aplica um golpe, já descontando as defesas, e retorna o dano
+// causado. Retorna false, sem fazer nada, se ainda estava invulnerável do
+// golpe anterior.
+func (b *BasicCombat) Hit(impact Impact) (DamageResult, bool) {
+	if b.invulnerableTicks > 0 {
+		return DamageResult{}, false
+	}
+	roll := 1.0
+	if impact.Rand != nil {
+		roll = impact.Rand.Float64()
+	}
+	result := ResolveDamage(impact.Damage, b.defense, roll)
+	b.Damage(result.Amount)
+	b.knockbackX, b.knockbackY = impact.KnockbackX, impact.KnockbackY
+	b.invulnerableTicks = impact.InvulnerableTicks
+	b.stunTicks = impact.StunTicks
+	for _, effect := range impact.Effects {
+		b.ApplyEffect(effect)
+	}
+	return result, true
+}
+
+// ApplyEffect adiciona um efeito de status. Retorna false se a entidade
+// é imune ao dano do efeito (resistência 1 ou mais).
+func (b *BasicCombat) ApplyEffect(def EffectDef) bool {
+	if def.DamagePerTick > 0 && b.defense.Resistances[def.DamageType] >= 1 {
+		return false
+	}
+	b.effects.Apply(def)
+	return true
+}
+
+func (b *BasicCombat) Effects() []*Effect {
+	return b.effects.Active()
+}
+
+// ClearEffects remove todos os efeitos de status.
+func (b *BasicCombat) ClearEffects() {
+	b.effects.Clear()
+}
+
+// ClearHit tira o empurrão, a invulnerabilidade e o atordoamento do
+// último golpe.
+func (b *BasicCombat) ClearHit() {
+	b.knockbackX, b.knockbackY = 0, 0
+	b.invulnerableTicks = 0
+	b.stunTicks = 0
+}
+
+func (b *BasicCombat) SpeedMultiplier() float64 {
+	return b.effects.SpeedMultiplier()
+}
+
+func (b *BasicCombat) AttackDamage() DamageInfo {
+	return DamageInfo{
+		Type:           b.damageType,
+		Amount:         b.attackPower,
+		CritChance:     b.critChance,
+		CritMultiplier: b.critMultiplier,
+	}
+}
+
+// SetAttackStyle define o tipo de dano e o crítico dos ataques normais.
+func (b *BasicCombat) SetAttackStyle(damageType DamageType, critChance, critMultiplier float64) {
+	b.damageType = damageType
+	b.critChance = critChance
+	b.critMultiplier = critMultiplier
+}
+
+func (b *BasicCombat) Defense() Defense {
+	return b.defense
+}
+
+func (b *BasicCombat) SetDefense(defense Defense) {
+	b.defense = defense
+}
+
+func (b *BasicCombat) Invulnerable() bool {
+	return b.invulnerableTicks > 0
+}
+
+// Stunned diz se um golpe ou efeito ainda não deixa agir.
+func (b *BasicCombat) Stunned() bool {
+	return b.stunTicks > 0 || b.effects.Stunned()
+}
+
+func (b *BasicCombat) Flashing() bool {
+	return b.invulnerableTicks > 0 && (b.invulnerableTicks/4)%2 == 1
+}
 
+// Knockback retorna o empurrão deste tick (0, 0 se não há nenhum).
+func (b *BasicCombat) Knockback() (float64, float64) {
+	return b.knockbackX, b.knockbackY
 }
 
 var _ Combat = (*BasicCombat)(nil)
@@ -89,6 +273,7 @@ func (e *EnemyCombat) Attack() bool {
 }
 
 func (e *EnemyCombat) Update() {
+	e.BasicCombat.Update()
 	e.timeSinceAttack += 1
 }
 
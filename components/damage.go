@@ -0,0 +1,77 @@
+package components
+
+import "math"
+
+// DamageType é o elemento de um ataque. Cada entidade pode resistir (ou
+// ser fraca) a cada tipo.
+type DamageType string
+
+const (
+	DamagePhysical DamageType = "physical"
+	DamageFire     DamageType = "fire"
+	DamagePoison   DamageType = "poison"
+	DamageMagic    DamageType = "magic"
+)
+
+// DefaultCritMultiplier é usado quando o ataque não define um.
+const DefaultCritMultiplier = 2.0
+
+// DamageInfo é um ataque antes das defesas de quem recebe.
+type DamageInfo struct {
+	Source         string // quem atacou (ex: "player", "skeleton")
+	Type           DamageType
+	Amount         int
+	CritChance     float64 // 0 a 1
+	CritMultiplier float64 // 0 usa DefaultCritMultiplier
+}
+
+// Defense são as defesas de uma entidade.
+type Defense struct {
+	// Armor é subtraído do dano físico.
+	Armor int
+	// Resistances reduz o dano de cada tipo: 0.5 toma metade, 1 é imune
+	// e valores negativos são fraquezas (-0.5 toma 50% a mais).
+	Resistances map[DamageType]float64
+}
+
+// DamageResult é o dano que de fato foi causado.
+type DamageResult struct {
+	Amount int
+	Type   DamageType
+	Crit   bool
+}
+
+// ResolveDamage calcula o dano de um ataque contra uma defesa. roll é um
+// número entre 0 e 1 sorteado por quem chama, usado para o crítico.
+// É o único lugar onde crítico, armadura e resistências são aplicados.
+func ResolveDamage(info DamageInfo, defense Defense, roll float64) DamageResult {
+	damageType := info.Type
+	if damageType == "" {
+		damageType = DamagePhysical
+	}
+	result := DamageResult{Type: damageType}
+
+	amount := float64(info.Amount)
+	if info.CritChance > 0 && roll < info.CritChance {
+		multiplier := info.CritMultiplier
+		if multiplier == 0 {
+			multiplier = DefaultCritMultiplier
+		}
+		amount *= multiplier
+		result.Crit = true
+	}
+
+	if damageType == DamagePhysical {
+		amount -= float64(defense.Armor)
+	}
+
+	resistance := math.Min(defense.Resistances[damageType], 1)
+	if resistance >= 1 {
+		return result // imune
+	}
+	amount *= 1 - resistance
+
+	// Um golpe que acerta sempre tira pelo menos 1 de vida
+	result.Amount = max(int(math.Round(amount)), 1)
+	return result
+}
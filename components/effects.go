@@ -0,0 +1,172 @@
+package components
+
+// EffectKind identifica um efeito de status.
+type EffectKind string
+
+const (
+	EffectPoison EffectKind = "poison"
+	EffectBurn   EffectKind = "burn"
+	EffectSlow   EffectKind = "slow"
+	EffectStun   EffectKind = "stun"
+	EffectRegen  EffectKind = "regen"
+)
+
+// Stacking diz o que acontece ao aplicar um efeito que já está ativo.
+type Stacking uint8
+
+const (
+	StackRefresh   Stacking = iota // recomeça a duração
+	StackIntensity                 // soma uma camada (até MaxStacks) e recomeça a duração
+	StackIgnore                    // o novo é ignorado até o atual acabar
+)
+
+// EffectDef descreve um efeito ao longo do tempo.
+type EffectDef struct {
+	Kind     EffectKind
+	Duration int // em ticks
+	Interval int // ticks entre cada aplicação de dano/cura
+
+	// Por aplicação e por camada: dano (com o tipo, passando pelas
+	// resistências) e cura
+	DamagePerTick int
+	DamageType    DamageType
+	HealPerTick   int
+
+	// SpeedMultiplier multiplica a velocidade (1 = normal). Com vários
+	// efeitos vale o menor.
+	SpeedMultiplier float64
+	// Stun impede de agir enquanto dura
+	Stun bool
+
+	Stacking  Stacking
+	MaxStacks int
+}
+
+// StandardEffects são os efeitos conhecidos, por nome. Arquétipos e itens
+// se referem a eles pelo EffectKind.
+var StandardEffects = map[EffectKind]EffectDef{
+	EffectPoison: {
+		Kind:            EffectPoison,
+		Duration:        300,
+		Interval:        60,
+		DamagePerTick:   1,
+		DamageType:      DamagePoison,
+		SpeedMultiplier: 1,
+		Stacking:        StackIntensity,
+		MaxStacks:       3,
+	},
+	EffectBurn: {
+		Kind:            EffectBurn,
+		Duration:        180,
+		Interval:        45,
+		DamagePerTick:   1,
+		DamageType:      DamageFire,
+		SpeedMultiplier: 1,
+		Stacking:        StackRefresh,
+	},
+	EffectSlow: {
+		Kind:            EffectSlow,
+		Duration:        120,
+		SpeedMultiplier: 0.5,
+		Stacking:        StackRefresh,
+	},
+	EffectStun: {
+		Kind:            EffectStun,
+		Duration:        45,
+		SpeedMultiplier: 1,
+		Stun:            true,
+		Stacking:        StackIgnore,
+	},
+	EffectRegen: {
+		Kind:            EffectRegen,
+		Duration:        300,
+		Interval:        60,
+		HealPerTick:     1,
+		SpeedMultiplier: 1,
+		Stacking:        StackRefresh,
+	},
+}
+
+// Effect é um efeito ativo numa entidade.
+type Effect struct {
+	EffectDef
+	Remaining int // ticks até acabar
+	Stacks    int
+	ticks     int
+}
+
+// Effects são os efeitos ativos de uma entidade.
+type Effects struct {
+	active []*Effect
+}
+
+// Apply adiciona um efeito seguindo as regras de acúmulo.
+func (e *Effects) Apply(def EffectDef) {
+	for _, effect := range e.active {
+		if effect.Kind != def.Kind {
+			continue
+		}
+		switch def.Stacking {
+		case StackIntensity:
+			effect.Stacks = min(effect.Stacks+1, max(def.MaxStacks, 1))
+			effect.Remaining = def.Duration
+		case StackRefresh:
+			effect.Remaining = def.Duration
+		}
+		return
+	}
+	e.active = append(e.active, &Effect{EffectDef: def, Remaining: def.Duration, Stacks: 1})
+}
+
+// Update avança um tick e retorna o dano e a cura que vencem neste tick,
+// por tipo de dano.
+func (e *Effects) Update() (damage map[DamageType]int, heal int) {
+	remaining := e.active[:0]
+	for _, effect := range e.active {
+		effect.ticks++
+		if effect.Interval > 0 && effect.ticks%effect.Interval == 0 {
+			if effect.DamagePerTick > 0 {
+				if damage == nil {
+					damage = make(map[DamageType]int)
+				}
+				damage[effect.DamageType] += effect.DamagePerTick * effect.Stacks
+			}
+			heal += effect.HealPerTick * effect.Stacks
+		}
+
+		effect.Remaining--
+		if effect.Remaining > 0 {
+			remaining = append(remaining, effect)
+		}
+	}
+	e.active = remaining
+	return damage, heal
+}
+
+// SpeedMultiplier é o menor multiplicador de velocidade entre os efeitos.
+func (e *Effects) SpeedMultiplier() float64 {
+	multiplier := 1.0
+	for _, effect := range e.active {
+		multiplier = min(multiplier, effect.SpeedMultiplier)
+	}
+	return multiplier
+}
+
+func (e *Effects) Stunned() bool {
+	for _, effect := range e.active {
+		if effect.Stun {
+			return true
+		}
+	}
+	return false
+}
+
+// Active retorna os efeitos ativos, na ordem em que foram aplicados.
+func (e *Effects) Active() []*Effect {
+	return e.active
+}
+
+// Clear remove todos os efeitos (ex: ao reviver).
+func (e *Effects) Clear() {
+	e.active = nil
+}
@@ -0,0 +1,91 @@
+package dialogue
+
+// maxJumps limita quantos nós sem fala uma conversa atravessa de uma vez,
+// para uma árvore com ciclo de desvios não travar o jogo.
+const maxJumps = 32
+
+// Conversation é uma conversa em andamento com um NPC.
+type Conversation struct {
+	tree    *Tree
+	world   World
+	node    *Node
+	choices []Choice // escolhas visíveis do nó atual
+}
+
+// Start começa uma conversa pelo nó inicial da árvore. Retorna nil se a
+// conversa acabar sem nenhuma fala.
+func Start(tree *Tree, world World) *Conversation {
+	c := &Conversation{tree: tree, world: world}
+	if !c.enter(tree.Start) {
+		return nil
+	}
+	return c
+}
+
+// enter vai para o nó name, executando as ações e seguindo os desvios
+// até chegar numa fala. Retorna false se a conversa acabou.
+func (c *Conversation) enter(name string) bool {
+	for jumps := 0; name != "" && jumps < maxJumps; jumps++ {
+		node, ok := c.tree.Nodes[name]
+		if !ok {
+			break
+		}
+		for _, action := range node.Actions {
+			action.Run(c.world)
+		}
+		if node.Text != "" {
+			c.node = node
+			c.choices = c.choices[:0]
+			for _, choice := range node.Choices {
+				if choice.If.Check(c.world) {
+					c.choices = append(c.choices, choice)
+				}
+			}
+			return true
+		}
+		name = c.branch(node)
+	}
+	c.node = nil
+	return false
+}
+
+// branch escolhe o próximo nó de um nó sem fala.
+func (c *Conversation) branch(node *Node) string {
+	for _, branch := range node.Branches {
+		if branch.If.Check(c.world) {
+			return branch.Next
+		}
+	}
+	return node.Next
+}
+
+// Node é a fala atual (nil se a conversa acabou).
+func (c *Conversation) Node() *Node {
+	return c.node
+}
+
+// Choices são as escolhas disponíveis na fala atual.
+func (c *Conversation) Choices() []Choice {
+	return c.choices
+}
+
+// Done diz se a conversa acabou.
+func (c *Conversation) Done() bool {
+	return c.node == nil
+}
+
+// Advance segue para a próxima fala de um nó sem escolhas.
+func (c *Conversation) Advance() {
+	if c.node == nil || len(c.choices) > 0 {
+		return
+	}
+	c.enter(c.branch(c.node))
+}
+
+// Choose segue pela escolha i.
+func (c *Conversation) Choose(i int) {
+	if c.node == nil || i < 0 || i >= len(c.choices) {
+		return
+	}
+	c.enter(c.choices[i].Next)
+}
@@ -0,0 +1,225 @@
+// Package dialogue carrega as árvores de diálogo dos NPCs e conduz uma
+// conversa: falas, escolhas, condições e ações.
+package dialogue
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Tree é uma árvore de diálogo, lida de um arquivo JSON em
+// assets/dialogue.
+type Tree struct {
+	Name  string           `json:"name"`
+	Start string           `json:"start"` // nó onde a conversa começa
+	Nodes map[string]*Node `json:"nodes"`
+}
+
+// Node é uma fala. Um nó sem Text só desvia a conversa pelos Branches.
+type Node struct {
+	Speaker string   `json:"speaker"`
+	Text    string   `json:"text"`
+	Actions []Action `json:"actions"` // executadas ao chegar no nó
+
+	// Depois da fala: o jogador escolhe entre Choices ou a conversa segue
+	// para Next ("" encerra). Branches escolhe o próximo nó pela primeira
+	// condição verdadeira.
+	Choices  []Choice `json:"choices"`
+	Branches []Branch `json:"branches"`
+	Next     string   `json:"next"`
+}
+
+type Choice struct {
+	Text string    `json:"text"`
+	Next string    `json:"next"`
+	If   Condition `json:"if"` // a escolha só aparece se for verdadeira
+}
+
+type Branch struct {
+	If   Condition `json:"if"`
+	Next string    `json:"next"`
+}
+
+// Condition é verdadeira quando todos os campos preenchidos são
+// verdadeiros. A condição vazia é sempre verdadeira.
+type Condition struct {
+	Flag    string `json:"flag"`     // a flag está ligada
+	NotFlag string `json:"not_flag"` // a flag está desligada
+	Item    string `json:"item"`     // o jogador tem o item...
+	Count   int    `json:"count"`    // ...pelo menos Count vezes (padrão 1)
+
+	// A missão Quest está em QuestStatus: "active" (padrão), "done" ou
+	// "none" (nunca foi começada)
+	Quest       string `json:"quest"`
+	QuestStatus string `json:"quest_status"`
+}
+
+// Situações de uma missão, como aparecem em Condition.QuestStatus.
+const (
+	QuestNone   = "none"
+	QuestActive = "active"
+	QuestDone   = "done"
+)
+
+// ActionType diz o que uma ação faz.
+type ActionType string
+
+const (
+	ActionSetFlag    ActionType = "set_flag"    // liga Flag
+	ActionClearFlag  ActionType = "clear_flag"  // desliga Flag
+	ActionGiveItem   ActionType = "give_item"   // dá Count de Item
+	ActionTakeItem   ActionType = "take_item"   // tira Count de Item
+	ActionHeal       ActionType = "heal"        // cura Amount (0 = vida cheia)
+	ActionStartQuest ActionType = "start_quest" // começa a missão Quest
+)
+
+type Action struct {
+	Type   ActionType `json:"type"`
+	Flag   string     `json:"flag"`
+	Item   string     `json:"item"`
+	Count  int        `json:"count"`
+	Amount int        `json:"amount"`
+	Quest  string     `json:"quest"`
+}
+
+// World é o que uma conversa consulta e altera no jogo.
+type World interface {
+	Flag(name string) bool
+	SetFlag(name string, value bool)
+	ItemCount(name string) int
+	GiveItem(name string, count int)
+	TakeItem(name string, count int)
+	Heal(amount int)
+	StartQuest(name string)
+	QuestStatus(name string) string // QuestNone, QuestActive ou QuestDone
+}
+
+// Check avalia a condição.
+func (c Condition) Check(w World) bool {
+	if c.Flag != "" && !w.Flag(c.Flag) {
+		return false
+	}
+	if c.NotFlag != "" && w.Flag(c.NotFlag) {
+		return false
+	}
+	if c.Item != "" && w.ItemCount(c.Item) < max(c.Count, 1) {
+		return false
+	}
+	if c.Quest != "" {
+		status := c.QuestStatus
+		if status == "" {
+			status = QuestActive
+		}
+		if w.QuestStatus(c.Quest) != status {
+			return false
+		}
+	}
+	return true
+}
+
+// Run executa a ação.
+func (a Action) Run(w World) {
+	switch a.Type {
+	case ActionSetFlag:
+		w.SetFlag(a.Flag, true)
+	case ActionClearFlag:
+		w.SetFlag(a.Flag, false)
+	case ActionGiveItem:
+		w.GiveItem(a.Item, max(a.Count, 1))
+	case ActionTakeItem:
+		w.TakeItem(a.Item, max(a.Count, 1))
+	case ActionHeal:
+		w.Heal(a.Amount)
+	case ActionStartQuest:
+		w.StartQuest(a.Quest)
+	}
+}
+
+// Registry guarda todas as árvores carregadas.
+type Registry struct {
+	byName map[string]*Tree
+}
+
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]*Tree)}
+}
+
+// Load lê todos os arquivos .json de um diretório.
+func Load(dir string) (*Registry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	r := NewRegistry()
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler o diálogo %s: %w", path, err)
+		}
+		tree := &Tree{}
+		if err := json.Unmarshal(contents, tree); err != nil {
+			return nil, fmt.Errorf("falha ao decodificar o diálogo %s: %w", path, err)
+		}
+		if tree.Name == "" {
+			tree.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if err := r.Add(tree); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// Add registra uma árvore, conferindo que todo nó citado existe.
+func (r *Registry) Add(tree *Tree) error {
+	if _, exists := r.byName[tree.Name]; exists {
+		return fmt.Errorf("diálogo %q definido duas vezes", tree.Name)
+	}
+	check := func(from, to string) error {
+		if to == "" {
+			return nil
+		}
+		if _, ok := tree.Nodes[to]; !ok {
+			return fmt.Errorf("diálogo %q: o nó %q leva ao nó %q, que não existe", tree.Name, from, to)
+		}
+		return nil
+	}
+	if _, ok := tree.Nodes[tree.Start]; !ok {
+		return fmt.Errorf("diálogo %q: nó inicial %q não existe", tree.Name, tree.Start)
+	}
+	for name, node := range tree.Nodes {
+		targets := []string{node.Next}
+		for _, choice := range node.Choices {
+			targets = append(targets, choice.Next)
+		}
+		for _, branch := range node.Branches {
+			targets = append(targets, branch.Next)
+		}
+		for _, to := range targets {
+			if err := check(name, to); err != nil {
+				return err
+			}
+		}
+		for _, action := range node.Actions {
+			switch action.Type {
+			case ActionSetFlag, ActionClearFlag, ActionGiveItem, ActionTakeItem, ActionHeal, ActionStartQuest:
+			default:
+				return fmt.Errorf("diálogo %q: ação desconhecida %q no nó %q", tree.Name, action.Type, name)
+			}
+		}
+	}
+	r.byName[tree.Name] = tree
+	return nil
+}
+
+// Get busca uma árvore pelo nome.
+func (r *Registry) Get(name string) (*Tree, bool) {
+	tree, ok := r.byName[name]
+	return tree, ok
+}
@@ -0,0 +1,184 @@
+package ecs
+
+import (
+	"image"
+	"rpg-go/ai"
+	"rpg-go/animations"
+	"rpg-go/archetypes"
+	"rpg-go/components"
+	"rpg-go/inventory"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Position é o canto superior esquerdo da entidade, em pixels.
+type Position struct {
+	X, Y float64
+}
+
+// Velocity é quanto a entidade quer andar neste tick.
+type Velocity struct {
+	Dx, Dy float64
+}
+
+// Sprite desenha o frame Frame de uma imagem dividida em frames de
+// FrameWidth x FrameHeight, Columns por linha.
+type Sprite struct {
+	Img                     *ebiten.Image
+	FrameWidth, FrameHeight int
+	Columns                 int
+	Frame                   int
+	Rotation                float64 // em radianos, em volta do centro do frame
+	OffsetY                 float64 // desloca só o desenho (ex: item pulando)
+}
+
+// Rect é o retângulo do frame atual dentro da imagem.
+func (s *Sprite) Rect() image.Rectangle {
+	columns := max(s.Columns, 1)
+	x := (s.Frame % columns) * s.FrameWidth
+	y := (s.Frame / columns) * s.FrameHeight
+	return image.Rect(x, y, x+s.FrameWidth, y+s.FrameHeight)
+}
+
+// Animation escolhe o frame do Sprite pela direção do movimento:
+// "down", "up", "left", "right", ou "idle" quando parada.
+type Animation struct {
+	Set     map[string]*animations.Animation
+	Current string
+}
+
+// Collider é o tamanho da entidade para colisões com o mapa, golpes e
+// coleta de itens.
+type Collider struct {
+	Width, Height float64
+}
+
+// Combat é a vida e o ataque da entidade.
+type Combat = components.EnemyCombat
+
+// AI é o cérebro de um inimigo.
+type AI = ai.Brain
+
+// Pickup é um item coletado ao encostar. Com Item ele vai para a bolsa;
+// sem, cura e aplica os efeitos na hora.
+type Pickup struct {
+	Heal    int
+	Effects []components.EffectDef
+
+	Item  *inventory.Item
+	Count int
+}
+
+// Flinch é a reação de um boneco de treino a um golpe: toca os frames
+// do sprite uma vez e volta ao primeiro.
+type Flinch struct {
+	Frames  int
+	Playing bool
+	tick    int
+	frame   int
+}
+
+func NewFlinch(frames int) *Flinch {
+	return &Flinch{
+		Frames: frames,
+		frame:  1 % frames, // parado mostra o segundo frame
+	}
+}
+
+func (f *Flinch) Hit() {
+	if !f.Playing {
+		f.Playing = true
+		f.tick = 0
+		f.frame = 1 % f.Frames // Começa a animação no segundo frame
+	}
+}
+
+func (f *Flinch) Update() {
+	if !f.Playing {
+		return
+	}
+
+	f.tick++
+	if f.tick > 10 {
+		f.tick = 0
+		f.frame = (f.frame + 1) % f.Frames
+	}
+	if f.tick == 0 && f.frame == 0 {
+		f.Playing = false
+	}
+}
+
+func (f *Flinch) Frame() int {
+	return f.frame
+}
+
+// Team diz de que lado está quem atirou um projétil.
+type Team uint8
+
+const (
+	TeamPlayer Team = iota // acerta inimigos e bonecos
+	TeamEnemy              // acerta o jogador
+)
+
+// Projectile anda pela Velocity até LifeSpan chegar a zero ou bater em algo.
+type Projectile struct {
+	Damage   components.DamageInfo
+	Effects  []components.EffectDef // aplicados em quem for atingido
+	LifeSpan int                    // em ticks
+	Team     Team
+	Spin     float64 // radianos por tick somados ao Sprite.Rotation
+}
+
+// Ranged é o ataque à distância de um inimigo.
+type Ranged struct {
+	Projectile *archetypes.Archetype
+	Cooldown   int // ticks entre arremessos
+	ticks      int
+}
+
+func (r *Ranged) Update() {
+	r.ticks++
+}
+
+// Fire retorna true se já pode arremessar, e recomeça a contagem.
+func (r *Ranged) Fire() bool {
+	if r.ticks < r.Cooldown {
+		return false
+	}
+	r.ticks = 0
+	return true
+}
+
+// Origin diz de onde a entidade veio.
+type Origin struct {
+	ObjectID  int                   // id do objeto no Tiled (0 se foi criada durante o jogo)
+	Archetype *archetypes.Archetype // definição usada para criá-la
+	Loot      string                // tabela de drops ("" se não deixa nada)
+}
+
+// NPC é um personagem com quem o jogador pode conversar.
+type NPC struct {
+	Name     string
+	Dialogue string // árvore de diálogo
+}
+
+// Pop é o pulinho de um drop saindo de quem morreu: anda de From até To
+// num arco e só pode ser coletado depois de pousar.
+type Pop struct {
+	FromX, FromY float64
+	ToX, ToY     float64
+	Height       float64 // altura do arco, em pixels
+	Duration     int     // em ticks
+	tick         int
+}
+
+// Update avança o pulo e retorna a posição e a altura atuais. done é true
+// no tick em que o drop pousa.
+func (p *Pop) Update() (x, y, height float64, done bool) {
+	p.tick++
+	t := min(float64(p.tick)/float64(max(p.Duration, 1)), 1)
+	x = p.FromX + (p.ToX-p.FromX)*t
+	y = p.FromY + (p.ToY-p.FromY)*t
+	height = p.Height * 4 * t * (1 - t)
+	return x, y, height, t >= 1
+}
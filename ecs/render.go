@@ -0,0 +1,51 @@
+package ecs
+
+import (
+	"rpg-go/camera"
+	"rpg-go/spritesheet"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SpriteView desenha uma entidade com Position e Sprite. Implementa
+// entities.Drawable, para ser ordenada junto com o jogador.
+type SpriteView struct {
+	Position *Position
+	Sprite   *Sprite
+}
+
+func (v SpriteView) GetY() float64 {
+	return v.Position.Y
+}
+
+func (v SpriteView) Draw(screen *ebiten.Image, cam *camera.Camera, _ *spritesheet.SpriteSheet) {
+	if v.Sprite.Img == nil {
+		return
+	}
+
+	opts := &ebiten.DrawImageOptions{}
+	if v.Sprite.Rotation != 0 {
+		halfW, halfH := float64(v.Sprite.FrameWidth)/2, float64(v.Sprite.FrameHeight)/2
+		opts.GeoM.Translate(-halfW, -halfH)
+		opts.GeoM.Rotate(v.Sprite.Rotation)
+		opts.GeoM.Translate(halfW, halfH)
+	}
+	opts.GeoM.Translate(v.Position.X, v.Position.Y+v.Sprite.OffsetY)
+	opts.GeoM.Translate(cam.X, cam.Y)
+
+	screen.DrawImage(v.Sprite.Img.SubImage(v.Sprite.Rect()).(*ebiten.Image), opts)
+}
+
+// SpriteViews retorna todas as entidades desenháveis.
+func (w *World) SpriteViews() []SpriteView {
+	entities := w.Query(w.Position, w.Sprite)
+	views := make([]SpriteView, 0, len(entities))
+	for _, e := range entities {
+		// Pisca enquanto está invulnerável depois de um golpe
+		if combat := w.Combat.Get(e); combat != nil && combat.Flashing() {
+			continue
+		}
+		views = append(views, SpriteView{Position: w.Position.Get(e), Sprite: w.Sprite.Get(e)})
+	}
+	return views
+}
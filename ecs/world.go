@@ -0,0 +1,176 @@
+// Package ecs guarda as entidades do mapa (inimigos, itens, bonecos...)
+// como ids com componentes. Os sistemas ficam em quem usa o mundo (a
+// GameScene) e percorrem as entidades com World.Query.
+package ecs
+
+import (
+	"image"
+	"sort"
+)
+
+// Entity é só um id. 0 nunca é usado e serve como "nenhuma entidade".
+type Entity uint32
+
+// Store guarda um tipo de componente por entidade.
+type Store[T any] struct {
+	items map[Entity]*T
+}
+
+func NewStore[T any]() *Store[T] {
+	return &Store[T]{items: make(map[Entity]*T)}
+}
+
+// Set adiciona (ou troca) o componente da entidade.
+func (s *Store[T]) Set(e Entity, c *T) {
+	s.items[e] = c
+}
+
+// Get retorna o componente da entidade, ou nil se ela não tem.
+func (s *Store[T]) Get(e Entity) *T {
+	return s.items[e]
+}
+
+func (s *Store[T]) Has(e Entity) bool {
+	_, ok := s.items[e]
+	return ok
+}
+
+func (s *Store[T]) Remove(e Entity) {
+	delete(s.items, e)
+}
+
+func (s *Store[T]) Len() int {
+	return len(s.items)
+}
+
+func (s *Store[T]) clear() {
+	s.items = make(map[Entity]*T)
+}
+
+func (s *Store[T]) entities() []Entity {
+	result := make([]Entity, 0, len(s.items))
+	for e := range s.items {
+		result = append(result, e)
+	}
+	return result
+}
+
+// componentStore é o que o World precisa saber de qualquer Store.
+type componentStore interface {
+	Has(e Entity) bool
+	Remove(e Entity)
+	Len() int
+	clear()
+	entities() []Entity
+}
+
+// World tem um Store para cada componente. Para um componente novo basta
+// adicionar o campo aqui e em NewWorld.
+type World struct {
+	next   Entity
+	stores []componentStore
+
+	Position   *Store[Position]
+	Velocity   *Store[Velocity]
+	Sprite     *Store[Sprite]
+	Animation  *Store[Animation]
+	Collider   *Store[Collider]
+	Combat     *Store[Combat]
+	AI         *Store[AI]
+	Pickup     *Store[Pickup]
+	Flinch     *Store[Flinch]
+	Projectile *Store[Projectile]
+	Ranged     *Store[Ranged]
+	Origin     *Store[Origin]
+	Pop        *Store[Pop]
+	NPC        *Store[NPC]
+}
+
+func NewWorld() *World {
+	w := &World{
+		Position:   NewStore[Position](),
+		Velocity:   NewStore[Velocity](),
+		Sprite:     NewStore[Sprite](),
+		Animation:  NewStore[Animation](),
+		Collider:   NewStore[Collider](),
+		Combat:     NewStore[Combat](),
+		AI:         NewStore[AI](),
+		Pickup:     NewStore[Pickup](),
+		Flinch:     NewStore[Flinch](),
+		Projectile: NewStore[Projectile](),
+		Ranged:     NewStore[Ranged](),
+		Origin:     NewStore[Origin](),
+		Pop:        NewStore[Pop](),
+		NPC:        NewStore[NPC](),
+	}
+	w.stores = []componentStore{
+		w.Position, w.Velocity, w.Sprite, w.Animation, w.Collider, w.Combat,
+		w.AI, w.Pickup, w.Flinch, w.Projectile, w.Ranged, w.Origin, w.Pop,
+		w.NPC,
+	}
+	return w
+}
+
+// Spawn cria uma entidade sem componentes.
+func (w *World) Spawn() Entity {
+	w.next++
+	return w.next
+}
+
+// Despawn remove todos os componentes da entidade.
+func (w *World) Despawn(e Entity) {
+	for _, s := range w.stores {
+		s.Remove(e)
+	}
+}
+
+// Clear remove todas as entidades (ex: ao trocar de mapa). Os ids não são
+// reaproveitados, então um id antigo nunca aponta para uma entidade nova.
+func (w *World) Clear() {
+	for _, s := range w.stores {
+		s.clear()
+	}
+}
+
+// Query retorna, em ordem de criação, as entidades que têm todos os
+// componentes pedidos. A lista é uma cópia: dá para criar e remover
+// entidades enquanto percorre.
+func (w *World) Query(stores ...componentStore) []Entity {
+	if len(stores) == 0 {
+		return nil
+	}
+
+	// Começa pelo menor store para checar menos entidades
+	smallest := stores[0]
+	for _, s := range stores[1:] {
+		if s.Len() < smallest.Len() {
+			smallest = s
+		}
+	}
+
+	result := make([]Entity, 0, smallest.Len())
+	for _, e := range smallest.entities() {
+		hasAll := true
+		for _, s := range stores {
+			if !s.Has(e) {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			result = append(result, e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// Bounds é o retângulo do colisor da entidade no mundo. Entidades sem
+// Position ou Collider retornam um retângulo vazio.
+func (w *World) Bounds(e Entity) image.Rectangle {
+	pos, col := w.Position.Get(e), w.Collider.Get(e)
+	if pos == nil || col == nil {
+		return image.Rectangle{}
+	}
+	return image.Rect(int(pos.X), int(pos.Y), int(pos.X+col.Width), int(pos.Y+col.Height))
+}
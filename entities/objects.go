@@ -9,13 +9,15 @@ import (
 type Objects struct {
 	img        *ebiten.Image
 	x, y, w, h float64
+	transform  ebiten.GeoM // espelhamento/giro do tile no Tiled
 }
 
-func NewObjects(img *ebiten.Image, x, y float64) *Objects {
+func NewObjects(img *ebiten.Image, x, y float64, transform ebiten.GeoM) *Objects {
 	return &Objects{
-		img: img,
-		x:   x,
-		y:   y,
+		img:       img,
+		x:         x,
+		y:         y,
+		transform: transform,
 	}
 }
 
@@ -25,7 +27,7 @@ func (o *Objects) GetY() float64 {
 func (o *Objects) Draw(screen *ebiten.Image, cam *camera.Camera, sheet *spritesheet.SpriteSheet) {
 	opts := &ebiten.DrawImageOptions{}
 
-	opts.GeoM.Reset()
+	opts.GeoM = o.transform
 	opts.GeoM.Translate(o.x, o.y)
 	opts.GeoM.Translate(cam.X, cam.Y)
 
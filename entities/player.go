@@ -1,10 +1,14 @@
 package entities
 
 import (
+	"image"
 	"math"
 	"rpg-go/animations"
 	"rpg-go/camera"
 	"rpg-go/components"
+	"rpg-go/constants"
+	"rpg-go/inventory"
+	"rpg-go/progression"
 	"rpg-go/spritesheet"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -25,14 +29,25 @@ type Player struct {
 	*Sprite
 	Animations map[PlayerState]*animations.Animation
 	CombatComp *components.BasicCombat
+	Progress   *progression.Progress
+	Inventory  *inventory.Inventory
+	Equipment  *inventory.Equipment
 
 	Facing      PlayerState
 	isAttacking bool // Atacando agora?
 	AttackTick  int  // Duração do ataque
+	ThrowTick   int  // Ticks até poder arremessar de novo
+
+	// attackDuration é quantos ticks dura um golpe (muda com a velocidade
+	// de ataque dos equipamentos)
+	attackDuration int
 }
 
+// PlayerCritChance é a chance de crítico do jogador sem equipamentos.
+const PlayerCritChance = 0.1
+
 func NewPlayer(img *ebiten.Image) *Player {
-	return &Player{
+	player := &Player{
 		Animations: map[PlayerState]*animations.Animation{
 			Up:          animations.NewAnimation(5, 13, 4, 20.0),
 			Down:        animations.NewAnimation(4, 12, 4, 20),
@@ -41,19 +56,28 @@ func NewPlayer(img *ebiten.Image) *Player {
 			AttackRight: animations.NewAnimation(19, 19, 0, 20),
 			AttackLeft:  animations.NewAnimation(18, 18, 0, 20),
 		},
-		Facing: Down,
+		Facing:         Down,
+		attackDuration: playerAttackDuration,
 
 		CombatComp: components.NewBasicCombat(10, 1), // Aumentei a vida para 10
+		Progress:   progression.NewProgress(),
+		Inventory:  inventory.New(inventory.DefaultSize),
+		Equipment:  inventory.NewEquipment(),
 		Sprite: &Sprite{
 			Img: img,
 		},
 	}
+	// Golpes de espada: dano físico, com 10% de chance de crítico
+	player.CombatComp.SetAttackStyle(components.DamagePhysical, PlayerCritChance, components.DefaultCritMultiplier)
+	return player
 }
 
 func (p *Player) ActiveAnimation() *animations.Animation {
 
 	if p.isAttacking {
-
+		if p.Facing == Left {
+			return p.Animations[AttackLeft]
+		}
 		return p.Animations[AttackRight]
 	}
 
@@ -80,6 +104,11 @@ func (p *Player) GetY() float64 {
 }
 
 func (p *Player) Draw(screen *ebiten.Image, cam *camera.Camera, sheet *spritesheet.SpriteSheet) {
+	// Pisca enquanto está invulnerável depois de um golpe
+	if p.CombatComp.Flashing() {
+		return
+	}
+
 	opts := &ebiten.DrawImageOptions{}
 	opts.GeoM.Translate(p.X, p.Y)
 	opts.GeoM.Translate(cam.X, cam.Y)
@@ -106,48 +135,128 @@ func (p *Player) Draw(screen *ebiten.Image, cam *camera.Camera, sheet *spriteshe
 	screen.DrawImage(p.Img.SubImage(frameRect).(*ebiten.Image), opts)
 }
 
-func (p *Player) Move() {
-	// React to keyPresses
-	p.Dx = 0.0
-	p.Dy = 0.0
-
-	if ebiten.IsKeyPressed(ebiten.KeyW) {
-		p.Dy = -2
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyS) {
-		p.Dy = 2
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyA) {
-		p.Dx = -2
+// Move define a velocidade do jogador a partir de uma direção vinda da
+// entrada (cada eixo entre -1 e 1).
+func (p *Player) Move(x, y float64) {
+	// Normalize movement, para a diagonal não ser mais rápida
+	magnitude := math.Sqrt(x*x + y*y)
+	if magnitude > 1.0 {
+		x /= magnitude
+		y /= magnitude
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyD) {
-		p.Dx += 2
+
+	speed := 2.0
+	p.Dx = x * speed
+	p.Dy = y * speed
+
+	// Olha para o eixo dominante do movimento
+	p.FaceTowards(x, y)
+}
+
+// FacingVector retorna a direção para onde o jogador está olhando.
+func (p *Player) FacingVector() (float64, float64) {
+	switch p.Facing {
+	case Up:
+		return 0, -1
+	case Left:
+		return -1, 0
+	case Right:
+		return 1, 0
+	default:
+		return 0, 1
 	}
-	// Normalize movement
-	// Magnitude
-	magnitude := math.Sqrt(p.Dx*p.Dx + p.Dy*p.Dy)
+}
 
-	if magnitude > 0.0 {
-		speed := 2.0
-		p.Dx = (p.Dx / magnitude) * speed
-		p.Dy = (p.Dy / magnitude) * speed
+const playerAttackDuration = 20
+
+// Ticks do ataque (em AttackTick) em que o golpe acerta: o começo e o
+// fim da animação são só o movimento da espada.
+const (
+	playerHitboxStart = 5
+	playerHitboxEnd   = 13
+)
+
+// SetAttackSpeed ajusta a duração do golpe: bonus 0.25 é 25% mais rápido.
+func (p *Player) SetAttackSpeed(bonus float64) {
+	duration := math.Round(playerAttackDuration / (1 + max(bonus, -0.5)))
+	p.attackDuration = max(int(duration), 4)
+}
+
+// AttackDuration é quantos ticks dura um golpe.
+func (p *Player) AttackDuration() int {
+	return p.attackDuration
+}
+
+// AttackHitbox retorna a área atingida pelo golpe neste tick: um tile na
+// frente do jogador, para onde ele está olhando. ok é false fora da parte
+// ativa do ataque.
+func (p *Player) AttackHitbox() (hitbox image.Rectangle, ok bool) {
+	// A parte ativa acompanha a duração do golpe
+	start := playerHitboxStart * p.attackDuration / playerAttackDuration
+	end := playerHitboxEnd * p.attackDuration / playerAttackDuration
+	if !p.isAttacking || p.AttackTick < start || p.AttackTick > end {
+		return image.Rectangle{}, false
 	}
 
+	fx, fy := p.FacingVector()
+	x := int(p.X) + int(fx)*constants.Tilesize
+	y := int(p.Y) + int(fy)*constants.Tilesize
+	return image.Rect(x, y, x+constants.Tilesize, y+constants.Tilesize), true
 }
 
-const playerAttackDuration = 20
+// FaceTowards vira o jogador para o eixo dominante da direção (dx, dy).
+func (p *Player) FaceTowards(dx, dy float64) {
+	if math.Abs(dx) > math.Abs(dy) {
+		if dx > 0 {
+			p.Facing = Right
+		} else {
+			p.Facing = Left
+		}
+	} else if dy != 0 {
+		if dy > 0 {
+			p.Facing = Down
+		} else {
+			p.Facing = Up
+		}
+	}
+}
 
 func (p *Player) IsAttacking() bool {
 	return p.isAttacking
 }
 
-func (p *Player) Attack() {
+// Attack começa um ataque e retorna true se um novo golpe começou agora
+// (false se o jogador já estava no meio de um ataque).
+func (p *Player) Attack() bool {
 	if !p.isAttacking {
 		p.isAttacking = true
 		p.AttackTick = 0
 		// Reinicia a animação de ataque correspondente
 		// p.Animations[p.facingAttackState()].Reset() // Veremos isso depois
+		return true
+	}
+	return false
+}
+
+// CancelActions interrompe o golpe em andamento e zera a espera do
+// arremesso.
+func (p *Player) CancelActions() {
+	p.isAttacking = false
+	p.AttackTick = 0
+	p.ThrowTick = 0
+	p.Dx, p.Dy = 0, 0
+}
+
+const playerThrowCooldown = 30
+
+// Throw retorna true se o jogador pode arremessar agora e começa a
+// contar o tempo até o próximo arremesso.
+func (p *Player) Throw() bool {
+	if p.ThrowTick > 0 {
+		return false
 	}
+	p.ThrowTick = playerThrowCooldown
+	return true
 }
 
 func (p *Player) SetFacing(dir PlayerState) {
@@ -157,16 +266,19 @@ func (p *Player) SetFacing(dir PlayerState) {
 func (p *Player) UpdateAttack() {
 	if p.isAttacking {
 		p.AttackTick++
-		if p.AttackTick >= playerAttackDuration {
+		if p.AttackTick >= p.attackDuration {
 			p.isAttacking = false // O tempo do ataque acabou, voltamos ao estado normal.
 		}
 	}
 }
 
 func (p *Player) UpdateAttackTick() {
+	if p.ThrowTick > 0 {
+		p.ThrowTick--
+	}
 	if p.isAttacking {
 		p.AttackTick++
-		if p.AttackTick >= playerAttackDuration {
+		if p.AttackTick >= p.attackDuration {
 			p.isAttacking = false
 			p.AttackTick = 0
 		}
@@ -0,0 +1,117 @@
+// Package events é o barramento de eventos do jogo: quem faz algo publica
+// um evento tipado (ex: EnemyDied) e quem se interessa (HUD, missões,
+// som...) se inscreve nele, sem um conhecer o outro.
+package events
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Bus entrega os eventos aos inscritos. Os inscritos de um tipo de evento
+// são chamados sempre na ordem em que se inscreveram.
+type Bus struct {
+	handlers map[reflect.Type][]*Subscription
+	queue    []queued
+	flushing bool
+}
+
+type queued struct {
+	key   reflect.Type
+	event any
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[reflect.Type][]*Subscription)}
+}
+
+// Subscription é a inscrição de uma função num tipo de evento.
+type Subscription struct {
+	bus    *Bus
+	key    reflect.Type
+	handle func(any)
+	active bool
+}
+
+// Subscribe inscreve handle nos eventos do tipo E. Quem se inscreve no meio
+// de uma entrega só recebe os eventos seguintes.
+func Subscribe[E any](b *Bus, handle func(E)) *Subscription {
+	key := reflect.TypeFor[E]()
+	sub := &Subscription{
+		bus:    b,
+		key:    key,
+		handle: func(event any) { handle(event.(E)) },
+		active: true,
+	}
+	b.handlers[key] = append(b.handlers[key], sub)
+	return sub
+}
+
+// Cancel desfaz a inscrição. Pode ser chamado de dentro de um handler: a
+// inscrição não recebe mais nada, nem o resto da entrega atual.
+func (s *Subscription) Cancel() {
+	if !s.active {
+		return
+	}
+	s.active = false
+	// Uma cópia nova, para não mexer na lista que uma entrega em
+	// andamento está percorrendo
+	s.bus.handlers[s.key] = slices.DeleteFunc(slices.Clone(s.bus.handlers[s.key]), func(other *Subscription) bool {
+		return other == s
+	})
+}
+
+// Publish entrega o evento na hora, antes de retornar.
+func Publish[E any](b *Bus, event E) {
+	b.dispatch(reflect.TypeFor[E](), event)
+}
+
+// Defer guarda o evento para ser entregue no próximo Flush (no fim do
+// tick), na ordem em que foi publicado. Serve para eventos que acontecem
+// no meio de um sistema, como mortes durante o combate.
+func Defer[E any](b *Bus, event E) {
+	b.queue = append(b.queue, queued{reflect.TypeFor[E](), event})
+}
+
+// Flush entrega os eventos guardados por Defer. Eventos adiados pelos
+// próprios handlers entram no fim da fila e também são entregues.
+func (b *Bus) Flush() {
+	if b.flushing {
+		return
+	}
+	b.flushing = true
+	for i := 0; i < len(b.queue); i++ {
+		b.dispatch(b.queue[i].key, b.queue[i].event)
+	}
+	clear(b.queue)
+	b.queue = b.queue[:0]
+	b.flushing = false
+}
+
+func (b *Bus) dispatch(key reflect.Type, event any) {
+	for _, sub := range b.handlers[key] {
+		if sub.active {
+			sub.handle(event)
+		}
+	}
+}
+
+// Scope junta inscrições que vivem o mesmo tanto (ex: enquanto uma cena
+// está ativa) para cancelar todas de uma vez.
+type Scope struct {
+	subs []*Subscription
+}
+
+// Add guarda a inscrição no escopo.
+func (s *Scope) Add(sub *Subscription) {
+	s.subs = append(s.subs, sub)
+}
+
+// Close cancela todas as inscrições do escopo. O escopo pode ser usado de
+// novo depois.
+func (s *Scope) Close() {
+	for _, sub := range s.subs {
+		sub.Cancel()
+	}
+	s.subs = nil
+}
@@ -0,0 +1,135 @@
+package events
+
+import "rpg-go/components"
+
+// Combate. Entidades são identificadas pelo nome do arquétipo; X e Y são
+// sempre o meio de quem sofreu a ação, em pixels do mapa.
+
+// PlayerAttacked: o jogador começou um golpe (ou arremessou, com Ranged).
+type PlayerAttacked struct {
+	X, Y   float64
+	Ranged bool
+}
+
+// EnemyDamaged: o jogador acertou um inimigo.
+type EnemyDamaged struct {
+	Archetype string
+	Damage    components.DamageResult
+	Health    int // vida que sobrou
+	X, Y      float64
+}
+
+// EnemyDied: um inimigo morreu.
+type EnemyDied struct {
+	Archetype string
+	ObjectID  int // id do objeto no Tiled (0 se foi criado durante o jogo)
+	X, Y      float64
+}
+
+// DummyHit: o jogador acertou um boneco de treino.
+type DummyHit struct {
+	Archetype string
+	X, Y      float64
+}
+
+// PlayerDamaged: o jogador tomou um golpe de Source.
+type PlayerDamaged struct {
+	Source string
+	Damage components.DamageResult
+	Health int
+	X, Y   float64
+}
+
+type PlayerDied struct {
+	Source string // quem deu o último golpe
+	X, Y   float64
+}
+
+type PlayerHealed struct {
+	Amount int
+	Health int
+}
+
+// Itens. Item é o nome do item em assets/items.
+
+// ItemCollected: o jogador pegou um item do chão.
+type ItemCollected struct {
+	Item  string
+	Title string
+	Count int
+	Total int // quantos tem agora
+	X, Y  float64
+}
+
+// ItemReceived: o jogador ganhou um item (de um diálogo ou missão).
+type ItemReceived struct {
+	Item  string
+	Title string
+	Count int
+}
+
+type ItemUsed struct {
+	Item   string
+	Title  string
+	Health int
+}
+
+// ItemUseRejected: o item não foi usado, ex: poção com a vida cheia.
+type ItemUseRejected struct {
+	Item   string
+	Title  string
+	Reason string
+}
+
+type ItemEquipped struct {
+	Item  string
+	Title string
+	Slot  string
+}
+
+type ItemUnequipped struct {
+	Item  string
+	Title string
+	Slot  string
+}
+
+// Progresso.
+
+type XPGained struct {
+	Amount int
+	Total  int
+}
+
+type LevelUp struct {
+	Level int
+}
+
+// MapChanged: o jogador passou por uma transição de From para To.
+type MapChanged struct {
+	From  string
+	To    string
+	Spawn string
+}
+
+// NPCTalked: o jogador começou a conversar com um NPC.
+type NPCTalked struct {
+	Archetype string
+	Name      string
+}
+
+type QuestStarted struct {
+	Quest string
+	Title string
+}
+
+// QuestAdvanced: a missão passou para a etapa Stage (o texto da etapa).
+type QuestAdvanced struct {
+	Quest string
+	Title string
+	Stage string
+}
+
+type QuestCompleted struct {
+	Quest string
+	Title string
+}
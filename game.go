@@ -1,7 +1,12 @@
 package main
 
 import (
+	"log"
+	"rpg-go/input"
+	"rpg-go/progression"
 	"rpg-go/scenes"
+	"rpg-go/sound"
+	"rpg-go/sound/ebitenaudio"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -9,24 +14,65 @@ import (
 type Game struct {
 	sceneMap      map[scenes.SceneId]scenes.Scene
 	activeSceneId scenes.SceneId
+	audio         *sound.Manager
 }
 
+const (
+	controlsPath = "assets/config/controls.json"
+	gameplayPath = "assets/config/gameplay.json"
+	audioPath    = "assets/config/audio.json"
+)
+
 func NewGame() *Game {
+	bindings, err := input.LoadBindings(controlsPath)
+	if err != nil {
+		log.Printf("Usando controles padrão: %v", err)
+		bindings = input.DefaultBindings()
+	}
+	in := input.NewHandler(input.NewEbitenSource(), bindings)
+
+	gameScene := scenes.NewGameScene(in)
+	penalty, err := scenes.LoadDeathPenalty(gameplayPath)
+	if err != nil {
+		log.Printf("Usando penalidade de morte padrão: %v", err)
+	}
+	gameScene.SetDeathPenalty(penalty)
+	curve, err := progression.LoadCurve(gameplayPath)
+	if err != nil {
+		log.Printf("Usando curva de níveis padrão: %v", err)
+	}
+	gameScene.SetLevelCurve(curve)
+	audioConfig, err := sound.LoadConfig(audioPath)
+	if err != nil {
+		log.Printf("Usando configuração de som padrão: %v", err)
+	}
+	audioManager := sound.NewManager(ebitenaudio.NewBackend(), audioConfig)
+	gameScene.SetAudio(audioManager)
+
 	sceneMap := map[scenes.SceneId]scenes.Scene{
-		scenes.GameSceneId:  scenes.NewGameScene(),
-		scenes.StartSceneId: scenes.NewStartScene(),
-		scenes.PauseSceneId: scenes.NewPauseScene(),
+		scenes.GameSceneId:      gameScene,
+		scenes.StartSceneId:     scenes.NewStartScene(in, gameScene),
+		scenes.PauseSceneId:     scenes.NewPauseScene(in, gameScene),
+		scenes.GameOverSceneId:  scenes.NewGameOverScene(in, gameScene),
+		scenes.StatsSceneId:     scenes.NewStatsScene(in, gameScene),
+		scenes.EquipmentSceneId: scenes.NewEquipmentScene(in, gameScene),
+		scenes.DialogueSceneId:  scenes.NewDialogueScene(in, gameScene),
+		scenes.QuestLogSceneId:  scenes.NewQuestLogScene(in, gameScene),
 	}
-	activeSceneId := scenes.GameSceneId
+	activeSceneId := scenes.StartSceneId
 	sceneMap[activeSceneId].FirstLoad()
 	return &Game{
 		sceneMap:      sceneMap,
 		activeSceneId: activeSceneId,
+		audio:         audioManager,
 	}
 
 }
 
 func (g *Game) Update() error {
+	// O crossfade da música anda mesmo com o jogo pausado
+	g.audio.Update()
+
 	nextSceneId := g.sceneMap[g.activeSceneId].Update()
 	if nextSceneId == scenes.ExitSceneId {
 		g.sceneMap[g.activeSceneId].OnExit()
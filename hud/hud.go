@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"image/color"
 	"rpg-go/components"
+	"rpg-go/inventory"
+	"rpg-go/progression"
+	"rpg-go/quests"
+	"sort"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -12,11 +17,57 @@ import (
 
 type HUD struct {
 	playerCombat components.Combat
+	progress     *progression.Progress
+	curve        progression.Curve
+
+	bannerTicks int // ticks que o aviso (ex: "LEVEL UP") ainda fica na tela
+	bannerText  string
+
+	inventory *inventory.Inventory
+	itemIcon  func(item *inventory.Item) *ebiten.Image
+	quests    *quests.Log
 }
 
-func NewHUD(playerCombat components.Combat) *HUD {
+func NewHUD(playerCombat components.Combat, progress *progression.Progress, curve progression.Curve) *HUD {
 	return &HUD{
 		playerCombat: playerCombat,
+		progress:     progress,
+		curve:        curve,
+	}
+}
+
+func (h *HUD) SetLevelCurve(curve progression.Curve) {
+	h.curve = curve
+}
+
+// SetInventory liga a hotbar à bolsa do jogador. icon dá a imagem de cada
+// item (pode retornar nil; aí o HUD mostra a inicial do nome).
+func (h *HUD) SetInventory(inv *inventory.Inventory, icon func(item *inventory.Item) *ebiten.Image) {
+	h.inventory = inv
+	h.itemIcon = icon
+}
+
+// SetQuests liga o acompanhamento de missões ao diário do jogador.
+func (h *HUD) SetQuests(log *quests.Log) {
+	h.quests = log
+}
+
+const bannerDuration = 120
+
+// Banner mostra um aviso no meio da tela por alguns segundos.
+func (h *HUD) Banner(text string) {
+	h.bannerTicks = bannerDuration
+	h.bannerText = text
+}
+
+// LevelUp mostra o aviso de que o jogador chegou ao nível level.
+func (h *HUD) LevelUp(level int) {
+	h.Banner(fmt.Sprintf("LEVEL UP! Level %d", level))
+}
+
+func (h *HUD) Update() {
+	if h.bannerTicks > 0 {
+		h.bannerTicks--
 	}
 }
 
@@ -29,12 +80,171 @@ func (h *HUD) Draw(screen *ebiten.Image) {
 	// Fundo da barra (vermelho escuro)
 	vector.DrawFilledRect(screen, px, 5, float32(barWidth), float32(barHeight), color.RGBA{100, 0, 0, 255}, false)
 
-	// Vida atual (verde)
+	// Vida atual (verde, piscando em branco logo depois de tomar um golpe)
+	healthColor := color.RGBA{0, 255, 0, 255}
+	if h.playerCombat.Flashing() {
+		healthColor = color.RGBA{255, 255, 255, 255}
+	}
 	healthPercentage := float32(h.playerCombat.Health()) / float32(h.playerCombat.MaxHealth())
 	currentHealthWidth := float32(barWidth) * healthPercentage
-	vector.DrawFilledRect(screen, px, 5, currentHealthWidth, float32(barHeight), color.RGBA{0, 255, 0, 255}, false)
+	vector.DrawFilledRect(screen, px, 5, currentHealthWidth, float32(barHeight), healthColor, false)
 
 	// Texto da vida
 	healthText := fmt.Sprintf("%d / %d", h.playerCombat.Health(), h.playerCombat.MaxHealth())
 	ebitenutil.DebugPrintAt(screen, healthText, int(px), 5)
+
+	// Nível e barra de experiência, no canto esquerdo
+	h.drawExperience(screen)
+
+	// Ícones dos efeitos de status, embaixo da barra de vida
+	for i, effect := range h.playerCombat.Effects() {
+		drawEffectIcon(screen, effect, px+float32(i*(effectIconWidth+2)), 24)
+	}
+
+	h.drawHotbar(screen)
+	h.drawQuestTracker(screen)
+
+	if h.bannerTicks > 0 {
+		// Pisca nos últimos ticks antes de sumir
+		if h.bannerTicks > 30 || (h.bannerTicks/5)%2 == 0 {
+			x := (screen.Bounds().Dx() - len(h.bannerText)*6) / 2
+			ebitenutil.DebugPrintAt(screen, h.bannerText, x, 60)
+		}
+	}
+}
+
+func (h *HUD) drawExperience(screen *ebiten.Image) {
+	if h.progress == nil {
+		return
+	}
+	barWidth := float32(50)
+	x, y := float32(5), float32(20)
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Lv %d", h.progress.Level), 5, 2)
+
+	// Parte da barra já preenchida dentro do nível atual
+	filled := float32(1)
+	if next, ok := h.curve.NextLevelXP(h.progress.Level); ok {
+		current := h.curve.Stats(h.progress.Level).XP
+		filled = float32(h.progress.XP-current) / float32(next-current)
+	}
+	vector.DrawFilledRect(screen, x, y, barWidth, 3, color.RGBA{40, 40, 80, 255}, false)
+	vector.DrawFilledRect(screen, x, y, barWidth*filled, 3, color.RGBA{90, 160, 255, 255}, false)
+}
+
+// drawQuestTracker mostra, embaixo da barra de experiência, a missão em
+// andamento mais antiga e os objetivos da etapa atual.
+func (h *HUD) drawQuestTracker(screen *ebiten.Image) {
+	if h.quests == nil {
+		return
+	}
+	active := h.quests.Active()
+	if len(active) == 0 {
+		return
+	}
+	entry := active[0]
+	stage := entry.Current()
+
+	y := 26
+	ebitenutil.DebugPrintAt(screen, entry.Quest.Title, 5, y)
+	for i, objective := range stage.Objectives {
+		y += 12
+		line := fmt.Sprintf("- %s", objective.Text)
+		if objective.Count > 1 {
+			line = fmt.Sprintf("- %s %d/%d", objective.Text, entry.Progress[i], objective.Count)
+		}
+		ebitenutil.DebugPrintAt(screen, line, 5, y)
+	}
+}
+
+const (
+	effectIconWidth  = 12
+	effectIconHeight = 16
+)
+
+// Cor e letra do ícone de cada efeito
+var effectIcons = map[components.EffectKind]struct {
+	color  color.RGBA
+	letter string
+}{
+	components.EffectPoison: {color.RGBA{120, 40, 160, 255}, "P"},
+	components.EffectBurn:   {color.RGBA{230, 100, 20, 255}, "B"},
+	components.EffectSlow:   {color.RGBA{40, 90, 200, 255}, "S"},
+	components.EffectStun:   {color.RGBA{200, 180, 30, 255}, "!"},
+	components.EffectRegen:  {color.RGBA{40, 170, 70, 255}, "+"},
+}
+
+func drawEffectIcon(screen *ebiten.Image, effect *components.Effect, x, y float32) {
+	icon, ok := effectIcons[effect.Kind]
+	if !ok {
+		icon.color, icon.letter = color.RGBA{128, 128, 128, 255}, "?"
+	}
+
+	vector.DrawFilledRect(screen, x, y, effectIconWidth, effectIconHeight, icon.color, false)
+	ebitenutil.DebugPrintAt(screen, icon.letter, int(x)+3, int(y))
+
+	// Barra com o tempo que falta
+	if effect.Duration > 0 {
+		left := float32(effect.Remaining) / float32(effect.Duration)
+		vector.DrawFilledRect(screen, x, y+effectIconHeight, effectIconWidth*left, 2, color.RGBA{255, 255, 255, 255}, false)
+	}
+
+	// Camadas acumuladas (ex: veneno x3)
+	if effect.Stacks > 1 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", effect.Stacks), int(x)+3, int(y)+effectIconHeight+2)
+	}
+}
+
+const hotbarSlotSize = 20
+
+// drawHotbar desenha os atalhos de uso rápido no pé da tela, com a tecla,
+// o ícone e quantos itens restam, e o dinheiro do jogador ao lado.
+func (h *HUD) drawHotbar(screen *ebiten.Image) {
+	if h.inventory == nil {
+		return
+	}
+	width := inventory.HotbarSize*(hotbarSlotSize+2) - 2
+	x0 := (screen.Bounds().Dx() - width) / 2
+	y := screen.Bounds().Dy() - hotbarSlotSize - 4
+
+	for i := 0; i < inventory.HotbarSize; i++ {
+		x := x0 + i*(hotbarSlotSize+2)
+		vector.DrawFilledRect(screen, float32(x), float32(y), hotbarSlotSize, hotbarSlotSize, color.RGBA{0, 0, 0, 160}, false)
+		vector.StrokeRect(screen, float32(x), float32(y), hotbarSlotSize, hotbarSlotSize, 1, color.RGBA{200, 200, 200, 255}, false)
+
+		name := h.inventory.Hotbar(i)
+		item, ok := h.inventory.Find(name)
+		if ok {
+			h.drawItemIcon(screen, item, x, y)
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", h.inventory.Count(name)), x+hotbarSlotSize-6, y+hotbarSlotSize-12)
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", i+1), x+1, y-2)
+	}
+
+	// Dinheiro, em ordem de nome para não trocar de lugar a cada frame
+	currency := h.inventory.Currency()
+	names := make([]string, 0, len(currency))
+	for name := range currency {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s: %d", name, currency[name]), x0+width+6, y+i*12)
+	}
+}
+
+func (h *HUD) drawItemIcon(screen *ebiten.Image, item *inventory.Item, x, y int) {
+	var icon *ebiten.Image
+	if h.itemIcon != nil {
+		icon = h.itemIcon(item)
+	}
+	if icon == nil {
+		ebitenutil.DebugPrintAt(screen, strings.ToUpper(item.Title[:1]), x+7, y+4)
+		return
+	}
+	// Centraliza o ícone no espaço
+	bounds := icon.Bounds()
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(x+(hotbarSlotSize-bounds.Dx())/2), float64(y+(hotbarSlotSize-bounds.Dy())/2))
+	screen.DrawImage(icon, opts)
 }
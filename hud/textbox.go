@@ -0,0 +1,156 @@
+package hud
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// LoadFont carrega uma fonte TrueType (ex: assets/fonts/Font.ttf) no
+// tamanho size, em pixels.
+func LoadFont(path string, size float64) (*text.GoTextFace, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler a fonte %s: %w", path, err)
+	}
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(contents))
+	if err != nil {
+		return nil, fmt.Errorf("falha ao decodificar a fonte %s: %w", path, err)
+	}
+	return &text.GoTextFace{Source: source, Size: size}, nil
+}
+
+// Geometria da caixa de texto, no pé da tela (320x240).
+const (
+	textBoxX       = 8
+	textBoxY       = 160
+	textBoxWidth   = 304
+	textBoxHeight  = 72
+	textBoxPadding = 8
+	textLineHeight = 12
+)
+
+// TextBox é a caixa de falas: mostra quem fala e o texto aparecendo aos
+// poucos, como numa máquina de escrever.
+type TextBox struct {
+	face    *text.GoTextFace
+	speaker string
+	lines   []string // o texto já quebrado para caber na caixa
+	total   int      // letras no texto todo
+	shown   float64  // letras já mostradas
+
+	// Speed é quantas letras aparecem por tick
+	Speed float64
+}
+
+func NewTextBox(face *text.GoTextFace) *TextBox {
+	return &TextBox{face: face, Speed: 1}
+}
+
+// SetText troca a fala e recomeça o efeito de máquina de escrever.
+func (b *TextBox) SetText(speaker, message string) {
+	b.speaker = speaker
+	b.lines = wrap(message, b.face, textBoxWidth-2*textBoxPadding)
+	b.total = 0
+	for _, line := range b.lines {
+		b.total += len([]rune(line))
+	}
+	b.shown = 0
+}
+
+func (b *TextBox) Update() {
+	b.shown = min(b.shown+b.Speed, float64(b.total))
+}
+
+// Skip mostra o texto todo de uma vez.
+func (b *TextBox) Skip() {
+	b.shown = float64(b.total)
+}
+
+// Done diz se o texto já apareceu todo.
+func (b *TextBox) Done() bool {
+	return int(b.shown) >= b.total
+}
+
+// Draw desenha a caixa. As escolhas só aparecem depois do texto todo,
+// numa caixa menor em cima, com a selecionada marcada.
+func (b *TextBox) Draw(screen *ebiten.Image, choices []string, selected int) {
+	vector.DrawFilledRect(screen, textBoxX, textBoxY, textBoxWidth, textBoxHeight, color.RGBA{20, 20, 40, 230}, false)
+	vector.StrokeRect(screen, textBoxX, textBoxY, textBoxWidth, textBoxHeight, 1, color.RGBA{230, 230, 230, 255}, false)
+
+	x, y := float64(textBoxX+textBoxPadding), float64(textBoxY+textBoxPadding)
+	if b.speaker != "" {
+		b.drawString(screen, b.speaker, x, y-4, color.RGBA{255, 210, 90, 255})
+		y += textLineHeight
+	}
+
+	left := int(b.shown)
+	for _, line := range b.lines {
+		runes := []rune(line)
+		if left < len(runes) {
+			runes = runes[:left]
+		}
+		b.drawString(screen, string(runes), x, y, color.White)
+		left -= len(runes)
+		y += textLineHeight
+		if left <= 0 {
+			break
+		}
+	}
+
+	if !b.Done() || len(choices) == 0 {
+		return
+	}
+	width := 0.0
+	for _, choice := range choices {
+		width = max(width, text.Advance("> "+choice, b.face))
+	}
+	boxW := float32(width) + 2*textBoxPadding
+	boxH := float32(len(choices)*textLineHeight + textBoxPadding)
+	boxX := float32(textBoxX+textBoxWidth) - boxW
+	boxY := float32(textBoxY) - boxH - 4
+	vector.DrawFilledRect(screen, boxX, boxY, boxW, boxH, color.RGBA{20, 20, 40, 230}, false)
+	vector.StrokeRect(screen, boxX, boxY, boxW, boxH, 1, color.RGBA{230, 230, 230, 255}, false)
+	for i, choice := range choices {
+		label := "  " + choice
+		if i == selected {
+			label = "> " + choice
+		}
+		b.drawString(screen, label, float64(boxX)+textBoxPadding, float64(boxY)+4+float64(i*textLineHeight), color.White)
+	}
+}
+
+func (b *TextBox) drawString(screen *ebiten.Image, s string, x, y float64, clr color.Color) {
+	opts := &text.DrawOptions{}
+	opts.GeoM.Translate(x, y)
+	opts.ColorScale.ScaleWithColor(clr)
+	text.Draw(screen, s, b.face, opts)
+}
+
+// wrap quebra o texto em linhas de no máximo width pixels, sem cortar
+// palavras. Quebras de linha do próprio texto são mantidas.
+func wrap(message string, face text.Face, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(message, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && text.Advance(candidate, face) > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,78 @@
+package input
+
+import "fmt"
+
+// Action é uma ação lógica do jogo. As cenas perguntam por ações
+// (ex: Attack), nunca por teclas; quem decide qual tecla/botão dispara
+// cada ação são as Bindings.
+type Action uint8
+
+const (
+	MoveUp Action = iota
+	MoveDown
+	MoveLeft
+	MoveRight
+	Attack
+	PointerAttack // ataque clicando no alvo com o mouse
+	Throw         // arremessa uma shuriken para onde o jogador olha
+	PointerThrow  // arremessa uma shuriken na direção do cursor
+	Interact
+	Pause
+	Stats    // abre a tela de atributos
+	QuestLog // abre o diário de missões
+	Hotbar1  // usa o item do primeiro atalho da hotbar
+	Hotbar2
+	Hotbar3
+	Hotbar4
+	Confirm
+	Cancel
+
+	actionCount
+)
+
+var actionNames = map[Action]string{
+	MoveUp:        "move_up",
+	MoveDown:      "move_down",
+	MoveLeft:      "move_left",
+	MoveRight:     "move_right",
+	Attack:        "attack",
+	PointerAttack: "pointer_attack",
+	Throw:         "throw",
+	PointerThrow:  "pointer_throw",
+	Interact:      "interact",
+	Pause:         "pause",
+	Stats:         "stats",
+	QuestLog:      "quest_log",
+	Hotbar1:       "hotbar_1",
+	Hotbar2:       "hotbar_2",
+	Hotbar3:       "hotbar_3",
+	Hotbar4:       "hotbar_4",
+	Confirm:       "confirm",
+	Cancel:        "cancel",
+}
+
+// Actions retorna todas as ações conhecidas, em ordem.
+func Actions() []Action {
+	actions := make([]Action, 0, actionCount)
+	for a := Action(0); a < actionCount; a++ {
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+func (a Action) String() string {
+	if name, ok := actionNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Action(%d)", a)
+}
+
+// ParseAction converte o nome usado no arquivo de configuração numa Action.
+func ParseAction(name string) (Action, error) {
+	for a, n := range actionNames {
+		if n == name {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("ação desconhecida: %q", name)
+}
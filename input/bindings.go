@@ -0,0 +1,230 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Binding lista tudo o que dispara uma ação. Basta um deles estar
+// pressionado para a ação contar como pressionada.
+type Binding struct {
+	Keys           []ebiten.Key
+	MouseButtons   []ebiten.MouseButton
+	GamepadButtons []ebiten.StandardGamepadButton
+}
+
+// Bindings associa cada ação às suas teclas/botões.
+type Bindings map[Action]Binding
+
+// DefaultBindings é o layout padrão (QWERTY + controle no layout padrão).
+func DefaultBindings() Bindings {
+	return Bindings{
+		MoveUp: {
+			Keys:           []ebiten.Key{ebiten.KeyW, ebiten.KeyArrowUp},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop},
+		},
+		MoveDown: {
+			Keys:           []ebiten.Key{ebiten.KeyS, ebiten.KeyArrowDown},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftBottom},
+		},
+		MoveLeft: {
+			Keys:           []ebiten.Key{ebiten.KeyA, ebiten.KeyArrowLeft},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftLeft},
+		},
+		MoveRight: {
+			Keys:           []ebiten.Key{ebiten.KeyD, ebiten.KeyArrowRight},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftRight},
+		},
+		Attack: {
+			Keys:           []ebiten.Key{ebiten.KeySpace},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+		},
+		PointerAttack: {
+			MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonLeft},
+		},
+		Throw: {
+			Keys:           []ebiten.Key{ebiten.KeyF},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightTop},
+		},
+		PointerThrow: {
+			MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonRight},
+		},
+		Interact: {
+			Keys:           []ebiten.Key{ebiten.KeyE},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightLeft},
+		},
+		Pause: {
+			Keys:           []ebiten.Key{ebiten.KeyEscape},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterRight},
+		},
+		Stats: {
+			Keys:           []ebiten.Key{ebiten.KeyTab},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterLeft},
+		},
+		QuestLog: {
+			Keys:           []ebiten.Key{ebiten.KeyJ},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftStick},
+		},
+		Hotbar1: {
+			Keys:           []ebiten.Key{ebiten.KeyDigit1},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonFrontTopLeft},
+		},
+		Hotbar2: {
+			Keys:           []ebiten.Key{ebiten.KeyDigit2},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonFrontTopRight},
+		},
+		Hotbar3: {
+			Keys:           []ebiten.Key{ebiten.KeyDigit3},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonFrontBottomLeft},
+		},
+		Hotbar4: {
+			Keys:           []ebiten.Key{ebiten.KeyDigit4},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonFrontBottomRight},
+		},
+		Confirm: {
+			Keys:           []ebiten.Key{ebiten.KeyEnter},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom},
+		},
+		Cancel: {
+			Keys:           []ebiten.Key{ebiten.KeyBackspace},
+			GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightRight},
+		},
+	}
+}
+
+// Clone devolve uma cópia independente das bindings.
+func (b Bindings) Clone() Bindings {
+	clone := make(Bindings, len(b))
+	for action, binding := range b {
+		clone[action] = Binding{
+			Keys:           slices.Clone(binding.Keys),
+			MouseButtons:   slices.Clone(binding.MouseButtons),
+			GamepadButtons: slices.Clone(binding.GamepadButtons),
+		}
+	}
+	return clone
+}
+
+var mouseButtonNames = map[ebiten.MouseButton]string{
+	ebiten.MouseButtonLeft:   "Left",
+	ebiten.MouseButtonRight:  "Right",
+	ebiten.MouseButtonMiddle: "Middle",
+	ebiten.MouseButton3:      "Back",
+	ebiten.MouseButton4:      "Forward",
+}
+
+var gamepadButtonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "RightBottom",
+	ebiten.StandardGamepadButtonRightRight:       "RightRight",
+	ebiten.StandardGamepadButtonRightLeft:        "RightLeft",
+	ebiten.StandardGamepadButtonRightTop:         "RightTop",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "FrontTopLeft",
+	ebiten.StandardGamepadButtonFrontTopRight:    "FrontTopRight",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "FrontBottomLeft",
+	ebiten.StandardGamepadButtonFrontBottomRight: "FrontBottomRight",
+	ebiten.StandardGamepadButtonCenterLeft:       "CenterLeft",
+	ebiten.StandardGamepadButtonCenterRight:      "CenterRight",
+	ebiten.StandardGamepadButtonLeftStick:        "LeftStick",
+	ebiten.StandardGamepadButtonRightStick:       "RightStick",
+	ebiten.StandardGamepadButtonLeftTop:          "LeftTop",
+	ebiten.StandardGamepadButtonLeftBottom:       "LeftBottom",
+	ebiten.StandardGamepadButtonLeftLeft:         "LeftLeft",
+	ebiten.StandardGamepadButtonLeftRight:        "LeftRight",
+	ebiten.StandardGamepadButtonCenterCenter:     "CenterCenter",
+}
+
+// bindingJSON é o formato de uma binding no arquivo de configuração.
+// Teclas usam os nomes do Ebiten ("W", "Space", "ArrowUp"...).
+type bindingJSON struct {
+	Keys    []ebiten.Key `json:"keys,omitempty"`
+	Mouse   []string     `json:"mouse,omitempty"`
+	Gamepad []string     `json:"gamepad,omitempty"`
+}
+
+func (b Binding) MarshalJSON() ([]byte, error) {
+	data := bindingJSON{Keys: b.Keys}
+	for _, button := range b.MouseButtons {
+		data.Mouse = append(data.Mouse, mouseButtonNames[button])
+	}
+	for _, button := range b.GamepadButtons {
+		data.Gamepad = append(data.Gamepad, gamepadButtonNames[button])
+	}
+	return json.Marshal(data)
+}
+
+func (b *Binding) UnmarshalJSON(contents []byte) error {
+	var data bindingJSON
+	if err := json.Unmarshal(contents, &data); err != nil {
+		return err
+	}
+
+	binding := Binding{Keys: data.Keys}
+	for _, name := range data.Mouse {
+		button, ok := lookupName(mouseButtonNames, name)
+		if !ok {
+			return fmt.Errorf("botão do mouse desconhecido: %q", name)
+		}
+		binding.MouseButtons = append(binding.MouseButtons, button)
+	}
+	for _, name := range data.Gamepad {
+		button, ok := lookupName(gamepadButtonNames, name)
+		if !ok {
+			return fmt.Errorf("botão de controle desconhecido: %q", name)
+		}
+		binding.GamepadButtons = append(binding.GamepadButtons, button)
+	}
+	*b = binding
+	return nil
+}
+
+func lookupName[T comparable](names map[T]string, name string) (T, bool) {
+	for value, n := range names {
+		if n == name {
+			return value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// LoadBindings lê um arquivo de controles. Ações que não aparecem no
+// arquivo ficam com a binding padrão.
+func LoadBindings(path string) (Bindings, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler o arquivo de controles %s: %w", path, err)
+	}
+
+	var raw map[string]Binding
+	if err := json.Unmarshal(contents, &raw); err != nil {
+		return nil, fmt.Errorf("falha ao decodificar o arquivo de controles %s: %w", path, err)
+	}
+
+	bindings := DefaultBindings()
+	for name, binding := range raw {
+		action, err := ParseAction(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		bindings[action] = binding
+	}
+	return bindings, nil
+}
+
+// SaveBindings grava as bindings no formato lido por LoadBindings.
+func SaveBindings(path string, bindings Bindings) error {
+	raw := make(map[string]Binding, len(bindings))
+	for action, binding := range bindings {
+		raw[action.String()] = binding
+	}
+
+	contents, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, contents, 0o644)
+}
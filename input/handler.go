@@ -0,0 +1,178 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultDeadzone é a zona morta padrão do analógico: inclinações menores
+// que isso são ignoradas (controles velhos nunca voltam exatamente a zero).
+const DefaultDeadzone = 0.25
+
+// Handler traduz o estado bruto de uma Source em ações do jogo.
+// As bindings podem ser trocadas a qualquer momento (ex: menu de controles).
+type Handler struct {
+	src      Source
+	bindings Bindings
+	deadzone float64
+	// held são ações ignoradas até serem soltas (ver WaitForRelease)
+	held map[Action]bool
+}
+
+func NewHandler(src Source, bindings Bindings) *Handler {
+	return &Handler{
+		src:      src,
+		bindings: bindings.Clone(),
+		deadzone: DefaultDeadzone,
+	}
+}
+
+// Update avança a fonte de entrada. Chame uma vez por tick.
+func (h *Handler) Update() {
+	h.src.Update()
+	for action := range h.held {
+		if !h.rawPressed(action) {
+			delete(h.held, action)
+		}
+	}
+}
+
+// WaitForRelease faz as ações pressionadas agora serem ignoradas até o
+// jogador soltar o botão. Usado ao trocar de cena, quando o mesmo botão
+// tem outra função (ex: o A do controle confirma no menu e ataca no jogo).
+func (h *Handler) WaitForRelease() {
+	h.held = make(map[Action]bool)
+	for action := range h.bindings {
+		if h.rawPressed(action) {
+			h.held[action] = true
+		}
+	}
+}
+
+// Pressed diz se a ação está sendo mantida neste tick.
+func (h *Handler) Pressed(action Action) bool {
+	return !h.held[action] && h.rawPressed(action)
+}
+
+func (h *Handler) rawPressed(action Action) bool {
+	binding := h.bindings[action]
+	for _, key := range binding.Keys {
+		if h.src.IsKeyPressed(key) {
+			return true
+		}
+	}
+	for _, button := range binding.MouseButtons {
+		if h.src.IsMouseButtonPressed(button) {
+			return true
+		}
+	}
+	for _, button := range binding.GamepadButtons {
+		if h.src.IsGamepadButtonPressed(button) {
+			return true
+		}
+	}
+	return false
+}
+
+// JustPressed diz se a ação começou exatamente neste tick.
+func (h *Handler) JustPressed(action Action) bool {
+	if h.held[action] {
+		return false
+	}
+	binding := h.bindings[action]
+	for _, key := range binding.Keys {
+		if h.src.IsKeyJustPressed(key) {
+			return true
+		}
+	}
+	for _, button := range binding.MouseButtons {
+		if h.src.IsMouseButtonJustPressed(button) {
+			return true
+		}
+	}
+	for _, button := range binding.GamepadButtons {
+		if h.src.IsGamepadButtonJustPressed(button) {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveVector retorna a direção pedida pelas ações de movimento,
+// com cada eixo entre -1 e 1 (sem normalizar a diagonal). Se nenhuma
+// ação digital estiver pressionada, usa o analógico esquerdo.
+func (h *Handler) MoveVector() (float64, float64) {
+	x, y := h.digitalMoveVector()
+	if x != 0 || y != 0 {
+		return x, y
+	}
+	return h.stickVector()
+}
+
+func (h *Handler) digitalMoveVector() (float64, float64) {
+	x, y := 0.0, 0.0
+	if h.Pressed(MoveUp) {
+		y -= 1
+	}
+	if h.Pressed(MoveDown) {
+		y += 1
+	}
+	if h.Pressed(MoveLeft) {
+		x -= 1
+	}
+	if h.Pressed(MoveRight) {
+		x += 1
+	}
+	return x, y
+}
+
+// stickVector lê o analógico esquerdo com zona morta radial. O valor é
+// reescalado para que a velocidade comece em zero logo depois da zona morta.
+func (h *Handler) stickVector() (float64, float64) {
+	x := h.src.GamepadAxis(ebiten.StandardGamepadAxisLeftStickHorizontal)
+	y := h.src.GamepadAxis(ebiten.StandardGamepadAxisLeftStickVertical)
+
+	magnitude := math.Sqrt(x*x + y*y)
+	if magnitude <= h.deadzone {
+		return 0, 0
+	}
+	scaled := math.Min(1, (magnitude-h.deadzone)/(1-h.deadzone))
+	return x / magnitude * scaled, y / magnitude * scaled
+}
+
+// SetDeadzone troca a zona morta do analógico (entre 0 e 1).
+func (h *Handler) SetDeadzone(deadzone float64) {
+	h.deadzone = math.Max(0, math.Min(deadzone, 0.95))
+}
+
+func (h *Handler) CursorPosition() (int, int) {
+	return h.src.CursorPosition()
+}
+
+// Binding retorna a binding atual de uma ação.
+func (h *Handler) Binding(action Action) Binding {
+	return h.bindings[action]
+}
+
+// Bindings retorna uma cópia de todas as bindings atuais.
+func (h *Handler) Bindings() Bindings {
+	return h.bindings.Clone()
+}
+
+// Bind substitui a binding de uma ação.
+func (h *Handler) Bind(action Action, binding Binding) {
+	h.bindings[action] = binding
+}
+
+// BindKey troca as teclas de uma ação, mantendo mouse e controle.
+func (h *Handler) BindKey(action Action, keys ...ebiten.Key) {
+	binding := h.bindings[action]
+	binding.Keys = keys
+	h.bindings[action] = binding
+}
+
+// SetBindings troca todas as bindings de uma vez (ex: ao recarregar o arquivo).
+func (h *Handler) SetBindings(bindings Bindings) {
+	h.bindings = bindings.Clone()
+}
@@ -0,0 +1,93 @@
+package input
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Frame descreve o estado da entrada durante um único tick.
+type Frame struct {
+	Keys             []ebiten.Key
+	MouseButtons     []ebiten.MouseButton
+	GamepadButtons   []ebiten.StandardGamepadButton
+	GamepadAxes      map[ebiten.StandardGamepadAxis]float64
+	CursorX, CursorY int
+}
+
+// ScriptedSource reproduz uma sequência de frames, um por tick.
+// Depois do último frame a entrada fica "solta" (nada pressionado).
+type ScriptedSource struct {
+	frames []Frame
+	tick   int // índice do frame atual; -1 antes do primeiro Update
+}
+
+func NewScriptedSource(frames ...Frame) *ScriptedSource {
+	return &ScriptedSource{
+		frames: frames,
+		tick:   -1,
+	}
+}
+
+// Push adiciona frames ao final do roteiro.
+func (s *ScriptedSource) Push(frames ...Frame) {
+	s.frames = append(s.frames, frames...)
+}
+
+// Hold adiciona o mesmo frame repetido por N ticks.
+func (s *ScriptedSource) Hold(frame Frame, ticks int) {
+	for i := 0; i < ticks; i++ {
+		s.frames = append(s.frames, frame)
+	}
+}
+
+// Idle adiciona N ticks sem nenhuma entrada.
+func (s *ScriptedSource) Idle(ticks int) {
+	s.Hold(Frame{}, ticks)
+}
+
+func (s *ScriptedSource) Update() {
+	s.tick++
+}
+
+func (s *ScriptedSource) frameAt(tick int) Frame {
+	if tick < 0 || tick >= len(s.frames) {
+		return Frame{}
+	}
+	return s.frames[tick]
+}
+
+func (s *ScriptedSource) IsKeyPressed(key ebiten.Key) bool {
+	return slices.Contains(s.frameAt(s.tick).Keys, key)
+}
+
+func (s *ScriptedSource) IsKeyJustPressed(key ebiten.Key) bool {
+	return s.IsKeyPressed(key) && !slices.Contains(s.frameAt(s.tick-1).Keys, key)
+}
+
+func (s *ScriptedSource) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return slices.Contains(s.frameAt(s.tick).MouseButtons, button)
+}
+
+func (s *ScriptedSource) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return s.IsMouseButtonPressed(button) && !slices.Contains(s.frameAt(s.tick-1).MouseButtons, button)
+}
+
+func (s *ScriptedSource) IsGamepadButtonPressed(button ebiten.StandardGamepadButton) bool {
+	return slices.Contains(s.frameAt(s.tick).GamepadButtons, button)
+}
+
+func (s *ScriptedSource) IsGamepadButtonJustPressed(button ebiten.StandardGamepadButton) bool {
+	return s.IsGamepadButtonPressed(button) && !slices.Contains(s.frameAt(s.tick-1).GamepadButtons, button)
+}
+
+func (s *ScriptedSource) CursorPosition() (int, int) {
+	f := s.frameAt(s.tick)
+	return f.CursorX, f.CursorY
+}
+
+func (s *ScriptedSource) GamepadAxis(axis ebiten.StandardGamepadAxis) float64 {
+	return s.frameAt(s.tick).GamepadAxes[axis]
+}
+
+var _ Source = (*ScriptedSource)(nil)
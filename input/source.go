@@ -0,0 +1,97 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Source abstrai de onde vem o estado dos dispositivos de entrada.
+// No jogo normal ela lê direto do Ebiten; nos testes (modo headless)
+// ela é alimentada por um roteiro de frames.
+type Source interface {
+	// Update avança a fonte para o próximo tick. Deve ser chamado uma vez
+	// no início de cada Update da cena.
+	Update()
+	IsKeyPressed(key ebiten.Key) bool
+	IsKeyJustPressed(key ebiten.Key) bool
+	IsMouseButtonPressed(button ebiten.MouseButton) bool
+	IsMouseButtonJustPressed(button ebiten.MouseButton) bool
+	CursorPosition() (int, int)
+	// Botões de controle usam o layout padrão e valem para qualquer
+	// controle conectado.
+	IsGamepadButtonPressed(button ebiten.StandardGamepadButton) bool
+	IsGamepadButtonJustPressed(button ebiten.StandardGamepadButton) bool
+	// GamepadAxis retorna o valor (-1 a 1) do eixo no controle em que ele
+	// está mais inclinado.
+	GamepadAxis(axis ebiten.StandardGamepadAxis) float64
+}
+
+// EbitenSource lê teclado, mouse e controles reais através do Ebiten.
+type EbitenSource struct {
+	gamepads []ebiten.GamepadID
+}
+
+func NewEbitenSource() *EbitenSource {
+	return &EbitenSource{}
+}
+
+// Update só atualiza a lista de controles conectados: o Ebiten já
+// atualiza o estado da entrada a cada tick.
+func (s *EbitenSource) Update() {
+	s.gamepads = ebiten.AppendGamepadIDs(s.gamepads[:0])
+}
+
+func (s *EbitenSource) IsKeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (s *EbitenSource) IsKeyJustPressed(key ebiten.Key) bool {
+	return inpututil.IsKeyJustPressed(key)
+}
+
+func (s *EbitenSource) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
+}
+
+func (s *EbitenSource) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustPressed(button)
+}
+
+func (s *EbitenSource) CursorPosition() (int, int) {
+	return ebiten.CursorPosition()
+}
+
+func (s *EbitenSource) IsGamepadButtonPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range s.gamepads {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && ebiten.IsStandardGamepadButtonPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *EbitenSource) IsGamepadButtonJustPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range s.gamepads {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *EbitenSource) GamepadAxis(axis ebiten.StandardGamepadAxis) float64 {
+	value := 0.0
+	for _, id := range s.gamepads {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		if v := ebiten.StandardGamepadAxisValue(id, axis); math.Abs(v) > math.Abs(value) {
+			value = v
+		}
+	}
+	return value
+}
+
+var _ Source = (*EbitenSource)(nil)
@@ -0,0 +1,169 @@
+package inventory
+
+// DefaultSize é quantos espaços a bolsa do jogador tem.
+const DefaultSize = 16
+
+// HotbarSize é quantos atalhos de uso rápido a hotbar tem.
+const HotbarSize = 4
+
+// Stack é um espaço da bolsa. Item é nil quando o espaço está vazio.
+type Stack struct {
+	Item  *Item
+	Count int
+}
+
+// Inventory é a bolsa do jogador: espaços com pilhas de itens, o dinheiro
+// (que não ocupa espaço) e a hotbar.
+type Inventory struct {
+	slots    []Stack
+	currency map[string]int
+
+	// hotbar guarda o nome do item de cada atalho: o atalho usa qualquer
+	// pilha desse item, e continua lá quando ele acaba
+	hotbar [HotbarSize]string
+}
+
+func New(size int) *Inventory {
+	return &Inventory{
+		slots:    make([]Stack, size),
+		currency: make(map[string]int),
+	}
+}
+
+// Add guarda count unidades do item e retorna quantas couberam.
+// Consumíveis novos vão para o primeiro atalho livre da hotbar.
+func (inv *Inventory) Add(item *Item, count int) int {
+	if count <= 0 {
+		return 0
+	}
+	if item.Kind == KindCurrency {
+		inv.currency[item.Name] += count
+		return count
+	}
+
+	added := 0
+	// Primeiro completa as pilhas que já existem, depois usa espaços vazios
+	for i := range inv.slots {
+		slot := &inv.slots[i]
+		if slot.Item == item && slot.Count < item.MaxStack {
+			n := min(item.MaxStack-slot.Count, count-added)
+			slot.Count += n
+			added += n
+		}
+	}
+	for i := range inv.slots {
+		if added == count {
+			break
+		}
+		slot := &inv.slots[i]
+		if slot.Item == nil {
+			n := min(item.MaxStack, count-added)
+			*slot = Stack{Item: item, Count: n}
+			added += n
+		}
+	}
+
+	if added > 0 && item.Usable() && inv.hotbarIndex(item.Name) < 0 {
+		for i, name := range inv.hotbar {
+			if name == "" {
+				inv.hotbar[i] = item.Name
+				break
+			}
+		}
+	}
+	return added
+}
+
+// Remove tira até count unidades do item e retorna quantas tirou.
+// As pilhas do fim da bolsa são gastas primeiro.
+func (inv *Inventory) Remove(name string, count int) int {
+	if _, ok := inv.currency[name]; ok {
+		n := min(inv.currency[name], count)
+		inv.currency[name] -= n
+		return n
+	}
+
+	removed := 0
+	for i := len(inv.slots) - 1; i >= 0 && removed < count; i-- {
+		slot := &inv.slots[i]
+		if slot.Item == nil || slot.Item.Name != name {
+			continue
+		}
+		n := min(slot.Count, count-removed)
+		slot.Count -= n
+		removed += n
+		if slot.Count == 0 {
+			*slot = Stack{}
+		}
+	}
+	return removed
+}
+
+// Count é quantas unidades do item o jogador tem.
+func (inv *Inventory) Count(name string) int {
+	if n, ok := inv.currency[name]; ok {
+		return n
+	}
+	total := 0
+	for _, slot := range inv.slots {
+		if slot.Item != nil && slot.Item.Name == name {
+			total += slot.Count
+		}
+	}
+	return total
+}
+
+// Find retorna a definição de um item que está na bolsa.
+func (inv *Inventory) Find(name string) (*Item, bool) {
+	for _, slot := range inv.slots {
+		if slot.Item != nil && slot.Item.Name == name {
+			return slot.Item, true
+		}
+	}
+	return nil, false
+}
+
+// Slots são os espaços da bolsa, em ordem. Não altere o retorno.
+func (inv *Inventory) Slots() []Stack {
+	return inv.slots
+}
+
+// Currency é quanto dinheiro de cada moeda o jogador tem.
+func (inv *Inventory) Currency() map[string]int {
+	return inv.currency
+}
+
+// Hotbar retorna o nome do item no atalho i ("" se vazio).
+func (inv *Inventory) Hotbar(i int) string {
+	if i < 0 || i >= HotbarSize {
+		return ""
+	}
+	return inv.hotbar[i]
+}
+
+// SetHotbar põe o item name no atalho i, tirando-o de onde estava antes.
+func (inv *Inventory) SetHotbar(i int, name string) {
+	if i < 0 || i >= HotbarSize {
+		return
+	}
+	if old := inv.hotbarIndex(name); old >= 0 && name != "" {
+		inv.hotbar[old] = ""
+	}
+	inv.hotbar[i] = name
+}
+
+func (inv *Inventory) hotbarIndex(name string) int {
+	for i, n := range inv.hotbar {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Clear esvazia a bolsa, o dinheiro e a hotbar.
+func (inv *Inventory) Clear() {
+	clear(inv.slots)
+	clear(inv.currency)
+	inv.hotbar = [HotbarSize]string{}
+}
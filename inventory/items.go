@@ -0,0 +1,159 @@
+// Package inventory define os itens do jogo e a bolsa do jogador.
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"rpg-go/components"
+	"sort"
+	"strings"
+)
+
+// Kind diz o que o jogador pode fazer com um item.
+type Kind string
+
+const (
+	KindConsumable Kind = "consumable" // usado pela hotbar (ex: poção)
+	KindEquipment  Kind = "equipment"  // vestido ou empunhado
+	KindKey        Kind = "key"        // itens de missão: não são usados nem gastos
+	KindCurrency   Kind = "currency"   // dinheiro: não ocupa espaço na bolsa
+)
+
+// Item é a definição de um tipo de item, lida de um arquivo JSON em
+// assets/items.
+type Item struct {
+	Name  string `json:"name"`
+	Title string `json:"title"` // nome mostrado ao jogador
+	Kind  Kind   `json:"kind"`
+	// MaxStack é quantos cabem num espaço da bolsa (padrão 1)
+	MaxStack int       `json:"max_stack"`
+	Icon     Icon      `json:"icon"`
+	Use      *UseDef   `json:"use,omitempty"`
+	Equip    *EquipDef `json:"equip,omitempty"`
+}
+
+// Icon é o frame da imagem usado para mostrar o item na hotbar e no chão.
+type Icon struct {
+	// Image é relativo ao arquivo de definição, como nos arquétipos.
+	Image       string `json:"image"`
+	FrameWidth  int    `json:"frame_width"`
+	FrameHeight int    `json:"frame_height"`
+	Columns     int    `json:"columns"`
+	Frame       int    `json:"frame"`
+}
+
+// Rect é a área do frame dentro da imagem.
+func (i Icon) Rect() image.Rectangle {
+	columns := max(i.Columns, 1)
+	x := (i.Frame % columns) * i.FrameWidth
+	y := (i.Frame / columns) * i.FrameHeight
+	return image.Rect(x, y, x+i.FrameWidth, y+i.FrameHeight)
+}
+
+// UseDef é o que um consumível faz ao ser usado.
+type UseDef struct {
+	Heal    int                     `json:"heal"`
+	Effects []components.EffectKind `json:"effects"`
+}
+
+// Usable diz se o item pode ser usado pela hotbar.
+func (i *Item) Usable() bool {
+	return i.Kind == KindConsumable && i.Use != nil
+}
+
+// Catalog guarda todas as definições de itens carregadas.
+type Catalog struct {
+	byName map[string]*Item
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{byName: make(map[string]*Item)}
+}
+
+// LoadCatalog lê todos os arquivos .json de um diretório.
+func LoadCatalog(dir string) (*Catalog, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	c := NewCatalog()
+	for _, path := range paths {
+		item, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Add(item); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return c, nil
+}
+
+func loadFile(path string) (*Item, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler o item %s: %w", path, err)
+	}
+	item := &Item{}
+	if err := json.Unmarshal(contents, item); err != nil {
+		return nil, fmt.Errorf("falha ao decodificar o item %s: %w", path, err)
+	}
+	if item.Name == "" {
+		item.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if item.Icon.Image != "" {
+		item.Icon.Image = filepath.Join(filepath.Dir(path), item.Icon.Image)
+	}
+	return item, nil
+}
+
+// Add registra uma definição.
+func (c *Catalog) Add(item *Item) error {
+	switch item.Kind {
+	case KindConsumable, KindEquipment, KindKey, KindCurrency:
+	default:
+		return fmt.Errorf("item %q: tipo desconhecido %q", item.Name, item.Kind)
+	}
+	if item.Kind == KindConsumable && item.Use == nil {
+		return fmt.Errorf("item %q: consumíveis precisam de \"use\"", item.Name)
+	}
+	if item.Kind == KindEquipment {
+		if item.Equip == nil {
+			return fmt.Errorf("item %q: equipamentos precisam de \"equip\"", item.Name)
+		}
+		switch item.Equip.Slot {
+		case SlotWeapon, SlotArmor, SlotAccessory:
+		default:
+			return fmt.Errorf("item %q: espaço de equipamento desconhecido %q", item.Name, item.Equip.Slot)
+		}
+	}
+	if item.Use != nil {
+		for _, kind := range item.Use.Effects {
+			if _, ok := components.StandardEffects[kind]; !ok {
+				return fmt.Errorf("item %q: efeito desconhecido %q", item.Name, kind)
+			}
+		}
+	}
+	if _, exists := c.byName[item.Name]; exists {
+		return fmt.Errorf("item %q definido duas vezes", item.Name)
+	}
+	if item.MaxStack < 1 {
+		item.MaxStack = 1
+	}
+	if item.Title == "" {
+		item.Title = item.Name
+	}
+	c.byName[item.Name] = item
+	return nil
+}
+
+// Get busca uma definição pelo nome.
+func (c *Catalog) Get(name string) (*Item, bool) {
+	item, ok := c.byName[name]
+	return item, ok
+}
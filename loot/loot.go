@@ -0,0 +1,165 @@
+// Package loot sorteia o que os inimigos deixam cair ao morrer.
+package loot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Entry é uma possibilidade de drop. Sem Item nem Archetype a entrada é
+// "nada": serve para dar peso à chance de não cair nada.
+type Entry struct {
+	Item      string `json:"item"`      // item de assets/items
+	Archetype string `json:"archetype"` // ou um arquétipo de assets/entities
+	Weight    int    `json:"weight"`
+	Min       int    `json:"min"` // quantidade (padrão 1)
+	Max       int    `json:"max"` // padrão Min
+}
+
+// Gold é o dinheiro que a tabela dá, fora dos sorteios.
+type Gold struct {
+	Item   string  `json:"item"` // padrão "gold"
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+	Chance float64 `json:"chance"` // 0 a 1 (padrão 1)
+}
+
+// Table é uma tabela de drops, lida de um arquivo JSON em assets/loot.
+type Table struct {
+	Name string `json:"name"`
+	// Rolls é quantas vezes Entries é sorteada (padrão 1)
+	Rolls int `json:"rolls"`
+	// Guaranteed sempre caem; Entries são sorteadas pelo peso
+	Guaranteed []Entry `json:"guaranteed"`
+	Entries    []Entry `json:"entries"`
+	Gold       *Gold   `json:"gold,omitempty"`
+}
+
+// Drop é algo sorteado: um item (com quantidade) ou um arquétipo.
+type Drop struct {
+	Item      string
+	Archetype string
+	Count     int
+}
+
+// Roll sorteia os drops da tabela. O sorteio só depende de rng, então
+// um rng com a mesma semente dá sempre os mesmos drops.
+func (t *Table) Roll(rng *rand.Rand) []Drop {
+	var drops []Drop
+	for _, entry := range t.Guaranteed {
+		drops = appendEntry(drops, entry, rng)
+	}
+
+	total := 0
+	for _, entry := range t.Entries {
+		total += entry.Weight
+	}
+	for i := 0; i < t.Rolls && total > 0; i++ {
+		pick := rng.IntN(total)
+		for _, entry := range t.Entries {
+			if pick < entry.Weight {
+				drops = appendEntry(drops, entry, rng)
+				break
+			}
+			pick -= entry.Weight
+		}
+	}
+
+	if t.Gold != nil && rng.Float64() < t.Gold.Chance {
+		amount := t.Gold.Min + rng.IntN(t.Gold.Max-t.Gold.Min+1)
+		if amount > 0 {
+			drops = append(drops, Drop{Item: t.Gold.Item, Count: amount})
+		}
+	}
+	return drops
+}
+
+func appendEntry(drops []Drop, entry Entry, rng *rand.Rand) []Drop {
+	if entry.Item == "" && entry.Archetype == "" {
+		return drops
+	}
+	count := entry.Min + rng.IntN(entry.Max-entry.Min+1)
+	return append(drops, Drop{Item: entry.Item, Archetype: entry.Archetype, Count: count})
+}
+
+// Registry guarda todas as tabelas carregadas.
+type Registry struct {
+	byName map[string]*Table
+}
+
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]*Table)}
+}
+
+// Load lê todos os arquivos .json de um diretório.
+func Load(dir string) (*Registry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	r := NewRegistry()
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler a tabela de drops %s: %w", path, err)
+		}
+		table := &Table{}
+		if err := json.Unmarshal(contents, table); err != nil {
+			return nil, fmt.Errorf("falha ao decodificar a tabela de drops %s: %w", path, err)
+		}
+		if table.Name == "" {
+			table.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if err := r.Add(table); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// Add registra uma tabela, preenchendo os valores padrão.
+func (r *Registry) Add(table *Table) error {
+	if _, exists := r.byName[table.Name]; exists {
+		return fmt.Errorf("tabela de drops %q definida duas vezes", table.Name)
+	}
+	if table.Rolls == 0 {
+		table.Rolls = 1
+	}
+	for _, entries := range [][]Entry{table.Guaranteed, table.Entries} {
+		for i := range entries {
+			entry := &entries[i]
+			if entry.Item != "" && entry.Archetype != "" {
+				return fmt.Errorf("tabela %q: a entrada %q escolhe item e arquétipo", table.Name, entry.Item)
+			}
+			if entry.Weight < 0 {
+				return fmt.Errorf("tabela %q: peso negativo em %q", table.Name, entry.Item+entry.Archetype)
+			}
+			entry.Min = max(entry.Min, 1)
+			entry.Max = max(entry.Max, entry.Min)
+		}
+	}
+	if gold := table.Gold; gold != nil {
+		if gold.Item == "" {
+			gold.Item = "gold"
+		}
+		if gold.Chance == 0 {
+			gold.Chance = 1
+		}
+		gold.Max = max(gold.Max, gold.Min)
+	}
+	r.byName[table.Name] = table
+	return nil
+}
+
+// Get busca uma tabela pelo nome.
+func (r *Registry) Get(name string) (*Table, bool) {
+	table, ok := r.byName[name]
+	return table, ok
+}
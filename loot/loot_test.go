@@ -0,0 +1,68 @@
+package loot_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"rpg-go/loot"
+)
+
+func TestRollSameSeedSameDrops(t *testing.T) {
+	registry, err := loot.Load("../assets/loot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, ok := registry.Get("skeleton")
+	if !ok {
+		t.Fatal("tabela skeleton não encontrada")
+	}
+
+	roll := func() [][]loot.Drop {
+		rng := rand.New(rand.NewPCG(1, 2))
+		var drops [][]loot.Drop
+		for range 50 {
+			drops = append(drops, table.Roll(rng))
+		}
+		return drops
+	}
+	if a, b := roll(), roll(); !reflect.DeepEqual(a, b) {
+		t.Fatalf("mesma semente, drops diferentes:\n%v\n%v", a, b)
+	}
+}
+
+func TestAddDefaults(t *testing.T) {
+	table := &loot.Table{
+		Name:       "teste",
+		Guaranteed: []loot.Entry{{Item: "potion"}},
+		Entries:    []loot.Entry{{Item: "bone", Weight: 1, Min: 2}},
+		Gold:       &loot.Gold{Min: 3},
+	}
+	if err := loot.NewRegistry().Add(table); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sem sorteio de verdade: uma entrada só, quantidades e ouro fixos
+	drops := table.Roll(rand.New(rand.NewPCG(1, 2)))
+	want := []loot.Drop{
+		{Item: "potion", Count: 1},
+		{Item: "bone", Count: 2},
+		{Item: "gold", Count: 3},
+	}
+	if !reflect.DeepEqual(drops, want) {
+		t.Fatalf("drops = %v, esperava %v", drops, want)
+	}
+}
+
+func TestRollEmptyEntry(t *testing.T) {
+	table := &loot.Table{
+		Name:    "nada",
+		Entries: []loot.Entry{{Weight: 1}},
+	}
+	if err := loot.NewRegistry().Add(table); err != nil {
+		t.Fatal(err)
+	}
+	if drops := table.Roll(rand.New(rand.NewPCG(1, 2))); len(drops) != 0 {
+		t.Fatalf("drops = %v, esperava nenhum", drops)
+	}
+}
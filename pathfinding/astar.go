@@ -0,0 +1,117 @@
+package pathfinding
+
+import (
+	"container/heap"
+	"math"
+)
+
+var neighbourOffsets = [8]Cell{
+	{0, -1}, {1, 0}, {0, 1}, {-1, 0}, // ortogonais
+	{1, -1}, {1, 1}, {-1, 1}, {-1, -1}, // diagonais
+}
+
+// FindPath procura o menor caminho entre duas células com A* (8 direções,
+// sem cortar quinas). A célula inicial e a final são aceitas mesmo que
+// estejam bloqueadas, porque o agente ou o alvo podem estar encostados
+// numa parede. Retorna nil se não houver caminho.
+func (n *NavGrid) FindPath(start, goal Cell) []Cell {
+	if !n.InBounds(start) || !n.InBounds(goal) {
+		return nil
+	}
+	if start == goal {
+		return []Cell{start}
+	}
+
+	open := &openSet{}
+	cameFrom := map[Cell]Cell{}
+	gScore := map[Cell]float64{start: 0}
+	closed := map[Cell]bool{}
+	order := 0 // desempate estável: nós inseridos antes saem antes
+
+	heap.Push(open, &node{cell: start, f: heuristic(start, goal)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*node).cell
+		if current == goal {
+			return reconstruct(cameFrom, current)
+		}
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+
+		for i, offset := range neighbourOffsets {
+			next := Cell{current.Col + offset.Col, current.Row + offset.Row}
+			if closed[next] || (!n.Walkable(next) && next != goal) {
+				continue
+			}
+			cost := 1.0
+			if i >= 4 {
+				// Diagonal só se as duas ortogonais estiverem livres
+				if !n.Walkable(Cell{current.Col + offset.Col, current.Row}) ||
+					!n.Walkable(Cell{current.Col, current.Row + offset.Row}) {
+					continue
+				}
+				cost = math.Sqrt2
+			}
+
+			tentative := gScore[current] + cost
+			if old, seen := gScore[next]; seen && tentative >= old {
+				continue
+			}
+			cameFrom[next] = current
+			gScore[next] = tentative
+			order++
+			heap.Push(open, &node{cell: next, f: tentative + heuristic(next, goal), order: order})
+		}
+	}
+	return nil
+}
+
+// heuristic é a distância octil, admissível para 8 direções.
+func heuristic(a, b Cell) float64 {
+	dx := math.Abs(float64(a.Col - b.Col))
+	dy := math.Abs(float64(a.Row - b.Row))
+	return (dx + dy) + (math.Sqrt2-2)*math.Min(dx, dy)
+}
+
+func reconstruct(cameFrom map[Cell]Cell, current Cell) []Cell {
+	path := []Cell{current}
+	for {
+		prev, ok := cameFrom[current]
+		if !ok {
+			break
+		}
+		path = append(path, prev)
+		current = prev
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+type node struct {
+	cell  Cell
+	f     float64
+	order int
+}
+
+// openSet é uma fila de prioridade (min-heap) pelo custo f.
+type openSet []*node
+
+func (o openSet) Len() int { return len(o) }
+func (o openSet) Less(i, j int) bool {
+	if o[i].f != o[j].f {
+		return o[i].f < o[j].f
+	}
+	return o[i].order < o[j].order
+}
+func (o openSet) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+func (o *openSet) Push(x any)   { *o = append(*o, x.(*node)) }
+func (o *openSet) Pop() any {
+	old := *o
+	n := old[len(old)-1]
+	*o = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,73 @@
+package pathfinding
+
+import (
+	"image"
+	"math"
+	"rpg-go/collisions"
+)
+
+// Cell é uma célula da grade de navegação (coluna, linha).
+type Cell struct {
+	Col, Row int
+}
+
+// NavGrid é a grade de células andáveis usada pelo A*. Cada célula tem
+// o tamanho de um tile; ela é bloqueada se qualquer colisor encostar nela.
+type NavGrid struct {
+	origin     Cell // primeira célula; mapas infinitos podem ter células negativas
+	cols, rows int
+	cellSize   int
+	blocked    []bool
+}
+
+// NewNavGrid monta a grade a partir dos colisores do mapa. Deve ser
+// chamado depois que todos os colisores foram inseridos no collisions.Grid.
+func NewNavGrid(grid *collisions.Grid, cellSize int) *NavGrid {
+	bounds := grid.Bounds()
+	n := &NavGrid{cellSize: cellSize}
+	n.origin = n.CellAt(float64(bounds.Min.X), float64(bounds.Min.Y))
+	last := n.CellAt(float64(bounds.Max.X-1), float64(bounds.Max.Y-1))
+	n.cols = max(last.Col-n.origin.Col+1, 0)
+	n.rows = max(last.Row-n.origin.Row+1, 0)
+	n.blocked = make([]bool, n.cols*n.rows)
+
+	for row := 0; row < n.rows; row++ {
+		for col := 0; col < n.cols; col++ {
+			c := Cell{n.origin.Col + col, n.origin.Row + row}
+			n.blocked[n.index(c)] = grid.Overlaps(n.cellRect(c))
+		}
+	}
+	return n
+}
+
+// index é a posição da célula em blocked.
+func (n *NavGrid) index(c Cell) int {
+	return (c.Row-n.origin.Row)*n.cols + c.Col - n.origin.Col
+}
+
+func (n *NavGrid) cellRect(c Cell) image.Rectangle {
+	x, y := c.Col*n.cellSize, c.Row*n.cellSize
+	return image.Rect(x, y, x+n.cellSize, y+n.cellSize)
+}
+
+func (n *NavGrid) InBounds(c Cell) bool {
+	col, row := c.Col-n.origin.Col, c.Row-n.origin.Row
+	return col >= 0 && col < n.cols && row >= 0 && row < n.rows
+}
+
+// Walkable diz se a célula existe e não tem colisor.
+func (n *NavGrid) Walkable(c Cell) bool {
+	return n.InBounds(c) && !n.blocked[n.index(c)]
+}
+
+// CellAt retorna a célula que contém o ponto (em pixels do mundo).
+func (n *NavGrid) CellAt(x, y float64) Cell {
+	size := float64(n.cellSize)
+	return Cell{int(math.Floor(x / size)), int(math.Floor(y / size))}
+}
+
+// Center retorna o centro da célula em pixels do mundo.
+func (n *NavGrid) Center(c Cell) (float64, float64) {
+	half := float64(n.cellSize) / 2
+	return float64(c.Col*n.cellSize) + half, float64(c.Row*n.cellSize) + half
+}
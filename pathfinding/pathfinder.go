@@ -0,0 +1,103 @@
+package pathfinding
+
+import "math"
+
+// maxCachedPaths limita o cache; quando enche, ele é esvaziado.
+const maxCachedPaths = 256
+
+// Pathfinder responde caminhos entre pontos do mundo, guardando os
+// caminhos já calculados. Crie um novo a cada mapa carregado.
+type Pathfinder struct {
+	nav   *NavGrid
+	cache map[[2]Cell][]Cell
+}
+
+func NewPathfinder(nav *NavGrid) *Pathfinder {
+	return &Pathfinder{
+		nav:   nav,
+		cache: make(map[[2]Cell][]Cell),
+	}
+}
+
+func (p *Pathfinder) Nav() *NavGrid {
+	return p.nav
+}
+
+// Path retorna as células do caminho entre dois pontos do mundo.
+// O slice devolvido é compartilhado com o cache e não deve ser alterado.
+func (p *Pathfinder) Path(fromX, fromY, toX, toY float64) []Cell {
+	key := [2]Cell{p.nav.CellAt(fromX, fromY), p.nav.CellAt(toX, toY)}
+	if path, ok := p.cache[key]; ok {
+		return path
+	}
+
+	path := p.nav.FindPath(key[0], key[1])
+	if len(p.cache) >= maxCachedPaths {
+		clear(p.cache)
+	}
+	p.cache[key] = path
+	return path
+}
+
+// Follower acompanha um caminho para um agente (inimigo, NPC...),
+// recalculando quando o alvo muda de célula.
+type Follower struct {
+	finder *Pathfinder
+	path   []Cell
+	next   int  // índice do próximo ponto do caminho
+	goal   Cell // célula do alvo quando o caminho foi calculado
+
+	// ReplanDelay é o mínimo de ticks entre dois recálculos, para um alvo
+	// que se mexe sem parar não custar um A* por tick.
+	ReplanDelay int
+	sinceReplan int
+}
+
+func NewFollower(finder *Pathfinder) *Follower {
+	return &Follower{
+		finder:      finder,
+		ReplanDelay: 15,
+	}
+}
+
+// NextWaypoint retorna o próximo ponto (centro de célula) para onde o
+// agente deve andar para chegar ao destino. ok é false se não houver caminho.
+func (f *Follower) NextWaypoint(fromX, fromY, toX, toY float64) (float64, float64, bool) {
+	nav := f.finder.nav
+	goal := nav.CellAt(toX, toY)
+	f.sinceReplan++
+
+	moved := goal != f.goal
+	if f.path == nil || (moved && f.sinceReplan >= f.ReplanDelay) {
+		f.path = f.finder.Path(fromX, fromY, toX, toY)
+		f.next = 0
+		f.goal = goal
+		f.sinceReplan = 0
+	}
+	if len(f.path) == 0 {
+		f.path = nil
+		return 0, 0, false
+	}
+
+	// Avança pelos pontos já alcançados
+	for f.next < len(f.path)-1 {
+		cx, cy := nav.Center(f.path[f.next])
+		if math.Hypot(cx-fromX, cy-fromY) > 1 {
+			break
+		}
+		f.next++
+	}
+
+	// Na última célula vai direto ao alvo
+	if f.next == len(f.path)-1 {
+		return toX, toY, true
+	}
+	x, y := nav.Center(f.path[f.next])
+	return x, y, true
+}
+
+// Reset esquece o caminho atual (ex: quando o agente muda de objetivo).
+func (f *Follower) Reset() {
+	f.path = nil
+	f.sinceReplan = 0
+}
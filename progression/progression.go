@@ -0,0 +1,118 @@
+// Package progression cuida da experiência e dos níveis do jogador.
+package progression
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Level são os atributos do jogador num nível.
+type Level struct {
+	XP          int `json:"xp"` // XP total para chegar neste nível
+	MaxHealth   int `json:"max_health"`
+	AttackPower int `json:"attack_power"`
+}
+
+// Curve é a tabela de níveis: Levels[0] é o nível 1.
+type Curve struct {
+	Levels []Level `json:"levels"`
+}
+
+func DefaultCurve() Curve {
+	return Curve{Levels: []Level{
+		{XP: 0, MaxHealth: 10, AttackPower: 1},
+		{XP: 10, MaxHealth: 12, AttackPower: 1},
+		{XP: 25, MaxHealth: 14, AttackPower: 2},
+		{XP: 45, MaxHealth: 16, AttackPower: 2},
+		{XP: 70, MaxHealth: 18, AttackPower: 3},
+		{XP: 100, MaxHealth: 20, AttackPower: 3},
+		{XP: 140, MaxHealth: 23, AttackPower: 4},
+		{XP: 190, MaxHealth: 26, AttackPower: 4},
+		{XP: 250, MaxHealth: 29, AttackPower: 5},
+		{XP: 320, MaxHealth: 32, AttackPower: 5},
+	}}
+}
+
+// LoadCurve lê a seção "leveling" do arquivo de gameplay. Sem a seção
+// vale a curva padrão.
+func LoadCurve(path string) (Curve, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return DefaultCurve(), fmt.Errorf("falha ao ler o arquivo de gameplay %s: %w", path, err)
+	}
+
+	var config struct {
+		Leveling *Curve `json:"leveling"`
+	}
+	if err := json.Unmarshal(contents, &config); err != nil {
+		return DefaultCurve(), fmt.Errorf("falha ao decodificar o arquivo de gameplay %s: %w", path, err)
+	}
+	if config.Leveling == nil {
+		return DefaultCurve(), nil
+	}
+	if err := config.Leveling.validate(); err != nil {
+		return DefaultCurve(), fmt.Errorf("%s: %w", path, err)
+	}
+	return *config.Leveling, nil
+}
+
+func (c Curve) validate() error {
+	if len(c.Levels) == 0 {
+		return errors.New("a curva de níveis está vazia")
+	}
+	if c.Levels[0].XP != 0 {
+		return errors.New("o nível 1 precisa de 0 de XP")
+	}
+	for i := 1; i < len(c.Levels); i++ {
+		if c.Levels[i].XP <= c.Levels[i-1].XP {
+			return fmt.Errorf("o nível %d precisa de mais XP que o nível %d", i+1, i)
+		}
+	}
+	return nil
+}
+
+func (c Curve) MaxLevel() int {
+	return len(c.Levels)
+}
+
+// Stats retorna os atributos de um nível (limitado aos níveis da tabela).
+func (c Curve) Stats(level int) Level {
+	level = min(max(level, 1), c.MaxLevel())
+	return c.Levels[level-1]
+}
+
+// NextLevelXP é a XP total para passar do nível level. ok é false no
+// nível máximo.
+func (c Curve) NextLevelXP(level int) (xp int, ok bool) {
+	if level >= c.MaxLevel() {
+		return 0, false
+	}
+	return c.Levels[level].XP, true
+}
+
+// Progress é o nível e a experiência acumulada do jogador.
+type Progress struct {
+	Level int
+	XP    int
+}
+
+func NewProgress() *Progress {
+	return &Progress{Level: 1}
+}
+
+// AddXP soma experiência e retorna quantos níveis o jogador subiu.
+func (p *Progress) AddXP(amount int, curve Curve) int {
+	p.XP += amount
+
+	gained := 0
+	for {
+		next, ok := curve.NextLevelXP(p.Level)
+		if !ok || p.XP < next {
+			return gained
+		}
+		p.Level++
+		gained++
+	}
+}
@@ -0,0 +1,142 @@
+package quests
+
+// Event é algo que aconteceu no jogo e pode contar para um objetivo, ex:
+// Event{ObjectiveKill, "skeleton"}.
+type Event struct {
+	Type   ObjectiveType
+	Target string
+}
+
+// Entry é uma missão que o jogador começou.
+type Entry struct {
+	Quest    *Quest
+	Stage    int   // etapa atual (len(Quest.Stages) quando terminou)
+	Progress []int // quantos eventos cada objetivo da etapa já contou
+}
+
+// Done diz se a missão terminou.
+func (e *Entry) Done() bool {
+	return e.Stage >= len(e.Quest.Stages)
+}
+
+// Current é a etapa atual (nil se a missão terminou).
+func (e *Entry) Current() *Stage {
+	if e.Done() {
+		return nil
+	}
+	return &e.Quest.Stages[e.Stage]
+}
+
+// notify conta o evento nos objetivos da etapa atual. Retorna true se a
+// etapa foi cumprida (e a missão passou para a próxima).
+func (e *Entry) notify(event Event) bool {
+	stage := e.Current()
+	if stage == nil {
+		return false
+	}
+	counted := false
+	for i, objective := range stage.Objectives {
+		if objective.Type == event.Type && objective.Target == event.Target && e.Progress[i] < objective.Count {
+			e.Progress[i]++
+			counted = true
+		}
+	}
+	if !counted {
+		return false
+	}
+	for i, objective := range stage.Objectives {
+		if e.Progress[i] < objective.Count {
+			return false
+		}
+	}
+	e.setStage(e.Stage + 1)
+	return true
+}
+
+func (e *Entry) setStage(stage int) {
+	e.Stage = min(max(stage, 0), len(e.Quest.Stages))
+	e.Progress = nil
+	if current := e.Current(); current != nil {
+		e.Progress = make([]int, len(current.Objectives))
+	}
+}
+
+// Log é o diário de missões do jogador: as que estão em andamento e as
+// terminadas, na ordem em que foram começadas.
+type Log struct {
+	entries []*Entry
+}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Start começa uma missão. Retorna nil se ela já foi começada.
+func (l *Log) Start(quest *Quest) *Entry {
+	if l.Get(quest.Name) != nil {
+		return nil
+	}
+	entry := &Entry{Quest: quest}
+	entry.setStage(0)
+	l.entries = append(l.entries, entry)
+	return entry
+}
+
+// Restore coloca de volta uma missão salva, na etapa stage e com o
+// progresso dos objetivos.
+func (l *Log) Restore(quest *Quest, stage int, progress []int) {
+	entry := l.Get(quest.Name)
+	if entry == nil {
+		entry = &Entry{Quest: quest}
+		l.entries = append(l.entries, entry)
+	}
+	entry.setStage(stage)
+	for i := range entry.Progress {
+		if i < len(progress) {
+			entry.Progress[i] = min(progress[i], entry.Current().Objectives[i].Count)
+		}
+	}
+}
+
+// Get busca uma missão começada pelo nome (nil se não foi começada).
+func (l *Log) Get(name string) *Entry {
+	for _, entry := range l.entries {
+		if entry.Quest.Name == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Entries são todas as missões começadas.
+func (l *Log) Entries() []*Entry {
+	return l.entries
+}
+
+// Active são as missões em andamento.
+func (l *Log) Active() []*Entry {
+	var active []*Entry
+	for _, entry := range l.entries {
+		if !entry.Done() {
+			active = append(active, entry)
+		}
+	}
+	return active
+}
+
+// Notify conta o evento em todas as missões em andamento. Retorna as que
+// passaram de etapa (as que terminaram têm Done verdadeiro).
+func (l *Log) Notify(event Event) []*Entry {
+	var advanced []*Entry
+	for _, entry := range l.entries {
+		if entry.notify(event) {
+			advanced = append(advanced, entry)
+		}
+	}
+	return advanced
+}
+
+// Clear esquece todas as missões.
+func (l *Log) Clear() {
+	l.entries = nil
+}
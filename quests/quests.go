@@ -0,0 +1,139 @@
+// Package quests carrega as missões e acompanha o progresso do jogador
+// nelas.
+package quests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ObjectiveType diz que tipo de evento conta para um objetivo.
+type ObjectiveType string
+
+const (
+	ObjectiveKill  ObjectiveType = "kill"  // matar Target (arquétipo)
+	ObjectiveHit   ObjectiveType = "hit"   // acertar Target (ex: o boneco de treino)
+	ObjectiveTalk  ObjectiveType = "talk"  // conversar com Target (arquétipo do NPC)
+	ObjectiveReach ObjectiveType = "reach" // passar por uma transição para o mapa Target
+)
+
+// Objective é uma tarefa de uma etapa: Count eventos do tipo Type com
+// Target.
+type Objective struct {
+	Type   ObjectiveType `json:"type"`
+	Target string        `json:"target"`
+	Count  int           `json:"count"` // padrão 1
+	Text   string        `json:"text"`  // mostrado no HUD e no diário
+}
+
+// Stage é uma etapa da missão. A missão passa para a próxima quando todos
+// os objetivos da etapa são cumpridos.
+type Stage struct {
+	Text       string      `json:"text"`
+	Objectives []Objective `json:"objectives"`
+}
+
+type ItemReward struct {
+	Item  string `json:"item"`
+	Count int    `json:"count"` // padrão 1
+}
+
+// Reward é o que o jogador ganha ao terminar a missão.
+type Reward struct {
+	XP    int          `json:"xp"`
+	Items []ItemReward `json:"items"`
+	Flags []string     `json:"flags"` // flags ligadas ao terminar
+}
+
+// Quest é uma missão, lida de um arquivo JSON em assets/quests.
+type Quest struct {
+	Name        string  `json:"name"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Stages      []Stage `json:"stages"`
+	Reward      Reward  `json:"reward"`
+}
+
+// Registry guarda todas as missões carregadas.
+type Registry struct {
+	byName map[string]*Quest
+}
+
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]*Quest)}
+}
+
+// Load lê todos os arquivos .json de um diretório.
+func Load(dir string) (*Registry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	r := NewRegistry()
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler a missão %s: %w", path, err)
+		}
+		quest := &Quest{}
+		if err := json.Unmarshal(contents, quest); err != nil {
+			return nil, fmt.Errorf("falha ao decodificar a missão %s: %w", path, err)
+		}
+		if quest.Name == "" {
+			quest.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if err := r.Add(quest); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// Add registra uma missão, conferindo as etapas e preenchendo os valores
+// padrão.
+func (r *Registry) Add(quest *Quest) error {
+	if _, exists := r.byName[quest.Name]; exists {
+		return fmt.Errorf("missão %q definida duas vezes", quest.Name)
+	}
+	if quest.Title == "" {
+		quest.Title = quest.Name
+	}
+	if len(quest.Stages) == 0 {
+		return fmt.Errorf("missão %q: nenhuma etapa", quest.Name)
+	}
+	for i := range quest.Stages {
+		stage := &quest.Stages[i]
+		if len(stage.Objectives) == 0 {
+			return fmt.Errorf("missão %q: a etapa %d não tem objetivos", quest.Name, i+1)
+		}
+		for j := range stage.Objectives {
+			objective := &stage.Objectives[j]
+			switch objective.Type {
+			case ObjectiveKill, ObjectiveHit, ObjectiveTalk, ObjectiveReach:
+			default:
+				return fmt.Errorf("missão %q: objetivo desconhecido %q", quest.Name, objective.Type)
+			}
+			if objective.Target == "" {
+				return fmt.Errorf("missão %q: objetivo %q sem alvo", quest.Name, objective.Type)
+			}
+			objective.Count = max(objective.Count, 1)
+		}
+	}
+	for i := range quest.Reward.Items {
+		quest.Reward.Items[i].Count = max(quest.Reward.Items[i].Count, 1)
+	}
+	r.byName[quest.Name] = quest
+	return nil
+}
+
+// Get busca uma missão pelo nome.
+func (r *Registry) Get(name string) (*Quest, bool) {
+	quest, ok := r.byName[name]
+	return quest, ok
+}
@@ -0,0 +1,157 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Version é a versão atual do formato do save. Aumente sempre que mudar
+// Data de um jeito incompatível e trate o caso antigo em migrate.
+const Version = 2
+
+// SlotCount é quantos slots de save o jogo oferece.
+const SlotCount = 3
+
+// Data é tudo que é gravado num slot.
+type Data struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+
+	// Map é o caminho do mapa do Tiled onde o jogador estava.
+	Map    string      `json:"map"`
+	Player PlayerState `json:"player"`
+
+	// Checkpoint é o último checkpoint tocado (nil se nenhum).
+	Checkpoint *Checkpoint `json:"checkpoint,omitempty"`
+
+	// World guarda o progresso de cada mapa, indexado pelo caminho do mapa.
+	World map[string]*MapState `json:"world"`
+
+	// Flags são as flags do jogo ligadas (ex: "met_master").
+	Flags []string `json:"flags,omitempty"`
+
+	// Quests são as missões começadas, na ordem em que foram começadas.
+	Quests []QuestState `json:"quests,omitempty"`
+}
+
+type PlayerState struct {
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Health    int     `json:"health"`
+	MaxHealth int     `json:"max_health"`
+	Level     int     `json:"level"`
+	XP        int     `json:"xp"`
+
+	// Inventory são os espaços da bolsa em ordem (Item vazio num espaço
+	// livre); Hotbar é o nome do item em cada atalho.
+	Inventory []ItemStack    `json:"inventory,omitempty"`
+	Hotbar    []string       `json:"hotbar,omitempty"`
+	Currency  map[string]int `json:"currency,omitempty"`
+	// Equipment é o nome do item vestido em cada espaço
+	Equipment map[string]string `json:"equipment,omitempty"`
+}
+
+type ItemStack struct {
+	Item  string `json:"item"`
+	Count int    `json:"count"`
+}
+
+// QuestState é uma missão começada: a etapa atual e quanto de cada
+// objetivo dela já foi feito.
+type QuestState struct {
+	Name     string `json:"name"`
+	Stage    int    `json:"stage"`
+	Progress []int  `json:"progress,omitempty"`
+}
+
+type Checkpoint struct {
+	Map string  `json:"map"`
+	X   float64 `json:"x"`
+	Y   float64 `json:"y"`
+}
+
+// MapState guarda o que mudou num mapa desde que ele foi carregado pela
+// primeira vez.
+type MapState struct {
+	// Removed são os ids (do Tiled) dos objetos que não devem mais
+	// aparecer: inimigos mortos, poções coletadas...
+	Removed []int `json:"removed"`
+}
+
+// SlotPath retorna o arquivo usado por um slot.
+func SlotPath(dir string, slot int) string {
+	return filepath.Join(dir, fmt.Sprintf("slot%d.json", slot))
+}
+
+// Write grava os dados no slot, criando o diretório se preciso.
+// O arquivo é escrito primeiro num temporário para não corromper um
+// save antigo caso o jogo feche no meio.
+func Write(dir string, slot int, data *Data) error {
+	if slot < 1 || slot > SlotCount {
+		return fmt.Errorf("slot inválido: %d", slot)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("falha ao criar o diretório de saves %s: %w", dir, err)
+	}
+
+	data.Version = Version
+	contents, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := SlotPath(dir, slot)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, contents, 0o644); err != nil {
+		return fmt.Errorf("falha ao gravar o save %s: %w", path, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// ErrEmptySlot é retornado por Read quando o slot ainda não tem save.
+var ErrEmptySlot = errors.New("slot vazio")
+
+// Read carrega um slot, migrando saves de versões antigas.
+func Read(dir string, slot int) (*Data, error) {
+	path := SlotPath(dir, slot)
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrEmptySlot
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler o save %s: %w", path, err)
+	}
+
+	var data Data
+	if err := json.Unmarshal(contents, &data); err != nil {
+		return nil, fmt.Errorf("falha ao decodificar o save %s: %w", path, err)
+	}
+	if err := migrate(&data); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &data, nil
+}
+
+// migrate atualiza um save antigo para a versão atual.
+func migrate(data *Data) error {
+	if data.Version > Version {
+		return fmt.Errorf("save da versão %d, mas o jogo só entende até a %d", data.Version, Version)
+	}
+	if data.Version < 1 {
+		return fmt.Errorf("versão de save inválida: %d", data.Version)
+	}
+	if data.World == nil {
+		data.World = map[string]*MapState{}
+	}
+	// Versão 1: ainda não havia níveis
+	if data.Version < 2 {
+		data.Player.Level = 1
+		data.Player.XP = 0
+	}
+	data.Version = Version
+	return nil
+}
@@ -0,0 +1,45 @@
+package scenes
+
+import (
+	"rpg-go/events"
+	"rpg-go/sound"
+)
+
+// SetAudio troca quem toca a música e os efeitos (por padrão um
+// sound.Manager nulo, que não toca nada).
+func (g *GameScene) SetAudio(audio *sound.Manager) {
+	g.audio = audio
+}
+
+// Audio é quem toca a música e os efeitos do jogo.
+func (g *GameScene) Audio() *sound.Manager {
+	return g.audio
+}
+
+// subscribeSounds liga os efeitos sonoros aos eventos do jogo enquanto a
+// cena está ativa. Os nomes dos efeitos são os de assets/config/audio.json.
+func (g *GameScene) subscribeSounds() {
+	g.sceneScope.Close()
+	scope, bus := &g.sceneScope, g.bus
+	scope.Add(events.Subscribe(bus, func(e events.PlayerAttacked) {
+		g.audio.PlaySound("attack", e.X, e.Y)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.EnemyDamaged) {
+		g.audio.PlaySound("hit", e.X, e.Y)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.DummyHit) {
+		g.audio.PlaySound("hit", e.X, e.Y)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.PlayerDamaged) {
+		g.audio.PlaySound("hurt", e.X, e.Y)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.ItemCollected) {
+		g.audio.PlaySound("pickup", e.X, e.Y)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.EnemyDied) {
+		g.audio.PlaySound("death", e.X, e.Y)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.PlayerDied) {
+		g.audio.PlaySound("death", e.X, e.Y)
+	}))
+}
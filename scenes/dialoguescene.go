@@ -0,0 +1,121 @@
+package scenes
+
+import (
+	"log"
+	"rpg-go/hud"
+	"rpg-go/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// fontPath é a fonte das caixas de texto.
+const fontPath = "assets/fonts/Font.ttf"
+
+// fontSize é o tamanho em que a fonte (pixel art) fica nítida.
+const fontSize = 9
+
+// DialogueScene mostra a conversa com um NPC por cima do jogo parado.
+type DialogueScene struct {
+	loaded   bool
+	input    *input.Handler
+	game     *GameScene
+	box      *hud.TextBox
+	selected int // escolha marcada, quando a fala tem escolhas
+}
+
+func NewDialogueScene(in *input.Handler, game *GameScene) *DialogueScene {
+	return &DialogueScene{
+		loaded: false,
+		input:  in,
+		game:   game,
+	}
+}
+
+func (s *DialogueScene) Draw(screen *ebiten.Image) {
+	s.game.Draw(screen)
+	if s.game.conversation == nil {
+		return
+	}
+	choices := s.game.conversation.Choices()
+	labels := make([]string, len(choices))
+	for i, choice := range choices {
+		labels[i] = choice.Text
+	}
+	s.box.Draw(screen, labels, s.selected)
+}
+
+// showNode põe a fala atual na caixa de texto.
+func (s *DialogueScene) showNode() {
+	node := s.game.conversation.Node()
+	speaker := node.Speaker
+	if speaker == "" && s.game.talkingTo != nil {
+		speaker = s.game.talkingTo.Name
+	}
+	s.box.SetText(speaker, node.Text)
+	s.selected = 0
+}
+
+func (s *DialogueScene) FirstLoad() {
+	face, err := hud.LoadFont(fontPath, fontSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.box = hud.NewTextBox(face)
+	s.loaded = true
+}
+
+func (s *DialogueScene) IsLoaded() bool {
+	return s.loaded
+}
+
+func (s *DialogueScene) OnEnter() {
+	if s.game.conversation != nil {
+		s.showNode()
+	}
+}
+
+func (s *DialogueScene) OnExit() {
+	s.game.conversation = nil
+	s.game.talkingTo = nil
+}
+
+func (s *DialogueScene) Update() SceneId {
+	s.input.Update()
+
+	conversation := s.game.conversation
+	if conversation == nil || conversation.Done() {
+		return GameSceneId
+	}
+	s.box.Update()
+
+	choices := conversation.Choices()
+	if s.box.Done() && len(choices) > 0 {
+		if s.input.JustPressed(input.MoveUp) {
+			s.selected = (s.selected - 1 + len(choices)) % len(choices)
+		}
+		if s.input.JustPressed(input.MoveDown) {
+			s.selected = (s.selected + 1) % len(choices)
+		}
+	}
+
+	if !s.input.JustPressed(input.Confirm) && !s.input.JustPressed(input.Interact) {
+		return DialogueSceneId
+	}
+	// O primeiro aperto só termina de escrever a fala
+	if !s.box.Done() {
+		s.box.Skip()
+		return DialogueSceneId
+	}
+	if len(choices) > 0 {
+		conversation.Choose(s.selected)
+	} else {
+		conversation.Advance()
+	}
+	if conversation.Done() {
+		return GameSceneId
+	}
+	s.showNode()
+	return DialogueSceneId
+}
+
+var _ Scene = (*DialogueScene)(nil)
@@ -0,0 +1,148 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"rpg-go/input"
+	"rpg-go/inventory"
+)
+
+// EquipmentScene mostra o que o jogador tem vestido e deixa trocar pelos
+// equipamentos da bolsa. É aberta pelo menu de pausa.
+type EquipmentScene struct {
+	loaded  bool
+	input   *input.Handler
+	game    *GameScene
+	slots   *menu  // um item por espaço de equipamento
+	active  *menu  // menu sendo mostrado: o de espaços ou o de itens
+	message string // resultado da última troca (ex: "Bag is full.")
+}
+
+func NewEquipmentScene(in *input.Handler, game *GameScene) *EquipmentScene {
+	return &EquipmentScene{
+		loaded: false,
+		input:  in,
+		game:   game,
+	}
+}
+
+func (s *EquipmentScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{40, 40, 60, 255})
+	ebitenutil.DebugPrintAt(screen, "EQUIPMENT", 128, 10)
+
+	s.active.Draw(screen, 10, 40)
+
+	y := 40
+	for _, line := range s.game.statLines() {
+		ebitenutil.DebugPrintAt(screen, line, 180, y)
+		y += 16
+	}
+
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 10, 210)
+	}
+}
+
+// buildSlotMenu refaz o menu de espaços com o que está vestido agora.
+func (s *EquipmentScene) buildSlotMenu() {
+	items := make([]menuItem, 0, len(inventory.EquipSlots)+1)
+	for _, slot := range inventory.EquipSlots {
+		label := slotTitle(slot) + ": -"
+		if item := s.game.player.Equipment.Get(slot); item != nil {
+			label = slotTitle(slot) + ": " + item.Title
+		}
+		items = append(items, menuItem{label, func() SceneId { return s.openItemMenu(slot) }})
+	}
+	items = append(items, menuItem{"Back", func() SceneId { return PauseSceneId }})
+
+	selected := 0
+	if s.slots != nil {
+		selected = s.slots.selected
+	}
+	s.slots = newMenu(items...)
+	s.slots.selected = selected
+	s.active = s.slots
+}
+
+// openItemMenu lista os equipamentos da bolsa que cabem no espaço, e uma
+// opção para tirar o que está vestido.
+func (s *EquipmentScene) openItemMenu(slot inventory.Slot) SceneId {
+	items := []menuItem{{"(remove)", func() SceneId {
+		if s.game.player.Equipment.Get(slot) != nil && !s.game.unequipSlot(slot) {
+			s.message = "Bag is full."
+		}
+		return s.backToSlots()
+	}}}
+
+	seen := make(map[string]bool)
+	for _, stack := range s.game.player.Inventory.Slots() {
+		item := stack.Item
+		if item == nil || item.Equip == nil || item.Equip.Slot != slot || seen[item.Name] {
+			continue
+		}
+		seen[item.Name] = true
+		items = append(items, menuItem{item.Title, func() SceneId {
+			if !s.game.equipItem(item) {
+				s.message = fmt.Sprintf("Could not equip %s.", item.Title)
+			}
+			return s.backToSlots()
+		}})
+	}
+	items = append(items, menuItem{"Back", s.backToSlots})
+
+	s.message = ""
+	s.active = newMenu(items...)
+	return EquipmentSceneId
+}
+
+func (s *EquipmentScene) backToSlots() SceneId {
+	s.buildSlotMenu()
+	return EquipmentSceneId
+}
+
+// slotTitle é o nome de um espaço nas telas, ex: "Weapon".
+func slotTitle(slot inventory.Slot) string {
+	name := string(slot)
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func (s *EquipmentScene) FirstLoad() {
+	s.loaded = true
+}
+
+func (s *EquipmentScene) IsLoaded() bool {
+	return s.loaded
+}
+
+func (s *EquipmentScene) OnEnter() {
+	s.slots = nil
+	s.message = ""
+	s.buildSlotMenu()
+}
+
+func (s *EquipmentScene) OnExit() {}
+
+func (s *EquipmentScene) Update() SceneId {
+	s.input.Update()
+
+	// Cancel na lista de itens volta para os espaços; nos espaços, para a pausa
+	if s.input.JustPressed(input.Cancel) {
+		if s.active != s.slots {
+			return s.backToSlots()
+		}
+		return PauseSceneId
+	}
+	if s.input.JustPressed(input.Pause) {
+		return GameSceneId
+	}
+	if next, ok := s.active.Update(s.input); ok {
+		return next
+	}
+	return EquipmentSceneId
+}
+
+var _ Scene = (*EquipmentScene)(nil)
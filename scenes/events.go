@@ -0,0 +1,81 @@
+package scenes
+
+import (
+	"path/filepath"
+	"rpg-go/constants"
+	"rpg-go/ecs"
+	"rpg-go/events"
+	"rpg-go/quests"
+	"strings"
+)
+
+// Events é o barramento de eventos do jogo. Outras partes (cenas, som...)
+// se inscrevem nele para saber o que acontece na GameScene; os eventos
+// adiados são entregues no fim de cada tick.
+func (g *GameScene) Events() *events.Bus {
+	return g.bus
+}
+
+// subscribeGame inscreve o que vale durante toda a vida da cena: a
+// experiência por inimigo morto.
+func (g *GameScene) subscribeGame() {
+	bus := g.bus
+	events.Subscribe(bus, func(e events.EnemyDied) {
+		if def, ok := g.archetypes.Get(e.Archetype); ok {
+			g.grantXP(def.XP)
+		}
+	})
+}
+
+// subscribeRun inscreve o HUD e o diário de missões do jogo atual. As
+// inscrições do jogo anterior são canceladas, junto com o HUD antigo.
+// Valem o jogo todo, não só com a cena ativa: missões começam e terminam
+// durante os diálogos e o aviso tem que aparecer na volta.
+func (g *GameScene) subscribeRun() {
+	g.runScope.Close()
+	scope, bus, hud := &g.runScope, g.bus, g.hud
+
+	scope.Add(events.Subscribe(bus, func(e events.LevelUp) {
+		hud.LevelUp(e.Level)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.QuestStarted) {
+		hud.Banner("New quest: " + e.Title)
+	}))
+	scope.Add(events.Subscribe(bus, func(events.QuestCompleted) {
+		hud.Banner("Quest complete!")
+	}))
+
+	scope.Add(events.Subscribe(bus, func(e events.EnemyDied) {
+		g.questEvent(quests.ObjectiveKill, e.Archetype)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.DummyHit) {
+		g.questEvent(quests.ObjectiveHit, e.Archetype)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.NPCTalked) {
+		g.questEvent(quests.ObjectiveTalk, e.Archetype)
+	}))
+	scope.Add(events.Subscribe(bus, func(e events.MapChanged) {
+		// Missões de chegar num lugar usam o nome do mapa, ex: "dojo"
+		g.questEvent(quests.ObjectiveReach, strings.TrimSuffix(filepath.Base(e.To), filepath.Ext(e.To)))
+	}))
+}
+
+// playerCenter é o meio do jogador, em pixels do mapa.
+func (g *GameScene) playerCenter() (float64, float64) {
+	return g.player.X + constants.Tilesize/2, g.player.Y + constants.Tilesize/2
+}
+
+// entityCenter é o meio da área de uma entidade.
+func (g *GameScene) entityCenter(e ecs.Entity) (float64, float64) {
+	bounds := g.world.Bounds(e)
+	return float64(bounds.Min.X+bounds.Max.X) / 2, float64(bounds.Min.Y+bounds.Max.Y) / 2
+}
+
+// archetypeName é o nome do arquétipo de uma entidade, que identifica
+// ela nos eventos ("" se não tem).
+func (g *GameScene) archetypeName(e ecs.Entity) string {
+	if origin := g.world.Origin.Get(e); origin != nil && origin.Archetype != nil {
+		return origin.Archetype.Name
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package scenes
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"rpg-go/input"
+)
+
+// GameOverScene aparece quando o jogador morre. Dá para tentar de novo
+// do último checkpoint, carregar um save ou voltar para a tela inicial.
+type GameOverScene struct {
+	loaded  bool
+	input   *input.Handler
+	game    *GameScene
+	main    *menu
+	active  *menu
+	message string
+}
+
+func NewGameOverScene(in *input.Handler, game *GameScene) *GameOverScene {
+	s := &GameOverScene{
+		loaded: false,
+		input:  in,
+		game:   game,
+	}
+	s.main = newMenu(
+		menuItem{"Retry", func() SceneId {
+			s.game.Respawn()
+			return GameSceneId
+		}},
+		menuItem{"Load game", s.openLoadMenu},
+		menuItem{"Quit to title", func() SceneId { return StartSceneId }},
+	)
+	s.active = s.main
+	return s
+}
+
+func (s *GameOverScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{60, 0, 0, 255})
+
+	ebitenutil.DebugPrintAt(screen, "GAME OVER", 130, 60)
+	s.active.Draw(screen, 100, 100)
+
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 100, 200)
+	}
+}
+
+func (s *GameOverScene) openLoadMenu() SceneId {
+	s.active = s.game.slotMenu(func(slot int) SceneId {
+		if err := s.game.LoadGame(slot); err != nil {
+			log.Println(err)
+			s.message = loadFailedMessage(slot, err)
+			return GameOverSceneId
+		}
+		return GameSceneId
+	}, s.backToMain)
+	return GameOverSceneId
+}
+
+func (s *GameOverScene) backToMain() SceneId {
+	s.active = s.main
+	return GameOverSceneId
+}
+
+func (s *GameOverScene) FirstLoad() {
+	s.loaded = true
+}
+
+func (s *GameOverScene) IsLoaded() bool {
+	return s.loaded
+}
+
+func (s *GameOverScene) OnEnter() {
+	s.main.selected = 0
+	s.active = s.main
+	s.message = ""
+}
+
+func (s *GameOverScene) OnExit() {
+}
+
+func (s *GameOverScene) Update() SceneId {
+	s.input.Update()
+
+	if s.input.JustPressed(input.Cancel) && s.active != s.main {
+		return s.backToMain()
+	}
+	if next, ok := s.active.Update(s.input); ok {
+		return next
+	}
+	return GameOverSceneId
+}
+
+var _ Scene = (*GameOverScene)(nil)
@@ -6,11 +6,25 @@ import (
 	"image/color"
 	"log"
 	"math"
+	"math/rand/v2"
+	"path/filepath"
+	"rpg-go/archetypes"
 	"rpg-go/camera"
 	"rpg-go/collisions"
+	"rpg-go/components"
 	"rpg-go/constants"
+	"rpg-go/dialogue"
+	"rpg-go/ecs"
 	"rpg-go/entities"
+	"rpg-go/events"
 	"rpg-go/hud"
+	"rpg-go/input"
+	"rpg-go/inventory"
+	"rpg-go/loot"
+	"rpg-go/pathfinding"
+	"rpg-go/progression"
+	"rpg-go/quests"
+	"rpg-go/sound"
 	"rpg-go/spritesheet"
 	"rpg-go/tilemap"
 	"rpg-go/tileset"
@@ -18,65 +32,184 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
+// startMap é o mapa onde um jogo novo começa.
+const startMap = "assets/maps/spawn.json"
+
+// DataDirs são as pastas com as definições que o jogo carrega ao abrir.
+type DataDirs struct {
+	Entities string // arquétipos dos inimigos, itens etc.
+	Items    string // itens que vão para a bolsa
+	Loot     string // tabelas de drops
+	Dialogue string // árvores de diálogo dos NPCs
+	Quests   string // missões
+}
+
+// DefaultDataDirs são as pastas do jogo, relativas à raiz do projeto.
+func DefaultDataDirs() DataDirs {
+	return DataDirs{
+		Entities: "assets/entities",
+		Items:    "assets/items",
+		Loot:     "assets/loot",
+		Dialogue: "assets/dialogue",
+		Quests:   "assets/quests",
+	}
+}
+
 type GameScene struct {
 	player            *entities.Player
+	playerImg         *ebiten.Image
 	playerSpriteSheet *spritesheet.SpriteSheet
 	CollisionGrid     *collisions.Grid
-
-	enemies     []*entities.Enemy
-	potions     []*entities.Potion
-	dummies     []*entities.TrainingDummy
-	projectiles []*entities.Projectile
-
+	pathfinder        *pathfinding.Pathfinder
+
+	// world guarda inimigos, itens, bonecos, projéteis... tudo que vem
+	// dos objetos do mapa ou é criado durante o jogo
+	world       *ecs.World
+	archetypes  *archetypes.Registry
+	items       *inventory.Catalog
+	lootTables  *loot.Registry
+	dialogues   *dialogue.Registry
+	quests      *quests.Registry
 	assets      *spritesheet.Assets
 	TilemapJSON *tilemap.TilemapJSON
 	Tilesets    []*tileset.Tileset
 	Camera      *camera.Camera
 	loaded      bool
 	hud         *hud.HUD
+
+	input      *input.Handler
+	headless   bool   // sem janela: não carrega imagens nem desenha
+	currentMap string // caminho do mapa carregado por LoadMap
+	swung      bool   // o jogador começou um golpe neste tick
+
+	// rng sorteia os drops e os críticos; com SetSeed o sorteio se repete
+	// (para testes)
+	rng *rand.Rand
+
+	// bus avisa o que acontece no jogo (ver Events); runScope são as
+	// inscrições do jogo atual, refeitas por startRun, e sceneScope as
+	// que só valem com a cena na tela (de OnEnter a OnExit)
+	bus        *events.Bus
+	runScope   events.Scope
+	sceneScope events.Scope
+	audio      *sound.Manager
+
+	// flags guardam o que já aconteceu no jogo (ver Flag)
+	flags        map[string]bool
+	conversation *dialogue.Conversation // conversa mostrada pela DialogueScene
+	talkingTo    *ecs.NPC
+	questLog     *quests.Log
+
+	// swingHits são as entidades já acertadas pelo golpe atual: cada
+	// golpe acerta cada alvo uma vez só
+	swingHits map[ecs.Entity]bool
+
+	// removedObjects guarda, por mapa, os ids do Tiled dos objetos que já
+	// foram mortos/coletados e não devem reaparecer.
+	removedObjects map[string]map[int]bool
+	saveDir        string
+
+	checkpointZones []image.Rectangle // objetos "checkpoint" do mapa atual
+	checkpoint      *respawnPoint     // último checkpoint tocado
+	entryPoint      respawnPoint      // onde o jogador entrou no mapa atual
+	deathPenalty    DeathPenalty
+	leveling        progression.Curve
 }
 
-func NewGameScene() *GameScene {
-	return &GameScene{
-		enemies:       make([]*entities.Enemy, 0),
-		potions:       make([]*entities.Potion, 0),
+func NewGameScene(in *input.Handler) *GameScene {
+	g := &GameScene{
+		world:         ecs.NewWorld(),
+		swingHits:     make(map[ecs.Entity]bool),
 		CollisionGrid: nil,
 		loaded:        false,
+		input:         in,
+
+		removedObjects: make(map[string]map[int]bool),
+		saveDir:        "saves",
+		deathPenalty:   DefaultDeathPenalty(),
+		leveling:       progression.DefaultCurve(),
+		rng:            rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		flags:          make(map[string]bool),
+		bus:            events.NewBus(),
+		audio:          sound.NewNullManager(),
 	}
+	g.subscribeGame()
+	return g
 }
 
 func (g *GameScene) FirstLoad() {
-	var err error
-	playerImg, _, err := ebitenutil.NewImageFromFile("./assets/images/ninja.png")
+	g.assets = spritesheet.NewAssets()
+	playerImg, err := g.assets.Image("assets/images/ninja.png")
 	if err != nil {
 		log.Fatal(err)
 	}
+	g.playerImg = playerImg
 
-	asset, err := spritesheet.LoadAssets()
-	if err != nil {
+	if err := g.loadData(DefaultDataDirs()); err != nil {
 		log.Fatal(err)
 	}
 
-	g.assets = asset
+	g.playerSpriteSheet = spritesheet.NewSpriteSheet(4, 7, constants.Tilesize)
+	g.Camera = camera.NewCamera(0, 0)
 
-	_, _, err = ebitenutil.NewImageFromFile("./assets/images/shuriken.png")
-	if err != nil {
+	// Carrega o mapa inicial e posiciona o jogador
+	if err := g.startRun(startMap, "default"); err != nil {
 		log.Fatal(err)
 	}
 
-	g.player = entities.NewPlayer(playerImg)
+	g.loaded = true
+}
 
-	g.playerSpriteSheet = spritesheet.NewSpriteSheet(4, 7, constants.Tilesize)
-	g.hud = hud.NewHUD(g.player.CombatComp)
-	g.Camera = camera.NewCamera(0, 0)
+// loadData carrega as definições de entidades, itens, drops, diálogos e
+// missões.
+func (g *GameScene) loadData(dirs DataDirs) error {
+	var err error
+	if g.archetypes, err = archetypes.Load(dirs.Entities); err != nil {
+		return err
+	}
+	if g.items, err = inventory.LoadCatalog(dirs.Items); err != nil {
+		return err
+	}
+	if g.lootTables, err = loot.Load(dirs.Loot); err != nil {
+		return err
+	}
+	if g.dialogues, err = dialogue.Load(dirs.Dialogue); err != nil {
+		return err
+	}
+	if g.quests, err = quests.Load(dirs.Quests); err != nil {
+		return err
+	}
+	return nil
+}
 
-	// Carrega o mapa inicial e posiciona o jogador
-	g.LoadMap("assets/maps/spawn.json", "default")
+// startRun começa um jogo do zero: jogador novo e mundo intacto.
+func (g *GameScene) startRun(mapPath, spawn string) error {
+	g.player = entities.NewPlayer(g.playerImg)
+	g.applyPlayerStats()
+	g.player.CombatComp.SetHealth(g.player.CombatComp.MaxHealth())
+	g.hud = hud.NewHUD(g.player.CombatComp, g.player.Progress, g.leveling)
+	g.hud.SetInventory(g.player.Inventory, g.itemIcon)
+	g.questLog = quests.NewLog()
+	g.hud.SetQuests(g.questLog)
+	g.subscribeRun()
+	g.removedObjects = make(map[string]map[int]bool)
+	g.flags = make(map[string]bool)
+	g.checkpoint = nil
+
+	return g.loadMap(mapPath, spawn)
+}
 
-	g.loaded = true
+// NewRun descarta o jogo atual e começa outro do mapa inicial.
+func (g *GameScene) NewRun() {
+	if !g.loaded {
+		g.FirstLoad()
+		return
+	}
+	if err := g.startRun(startMap, "default"); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func (g *GameScene) Draw(screen *ebiten.Image) {
@@ -91,28 +224,36 @@ func (g *GameScene) Draw(screen *ebiten.Image) {
 		if layer.Type != "tilelayer" {
 			continue
 		}
-		for i, tileID := range layer.Data {
-			if tileID == 0 {
+		for i, gid := range layer.Data {
+			// O GID pode vir com bits de espelhamento/giro
+			tile := tilemap.DecodeGID(gid)
+			if tile.ID == 0 {
 				continue
 			}
 
-			tileset := g.findTilesetForTile(tileID)
+			tileset := g.findTilesetForTile(tile.ID)
 
 			if tileset == nil {
 				continue
 			}
+			img := tileset.Img(tile.ID)
+			if img == nil {
+				continue
+			}
+			size := img.Bounds().Size()
+			transform := tile.GeoM(float64(size.X), float64(size.Y))
 
-			x := float64((i % layer.Width) * constants.Tilesize)
-			y := float64((i / layer.Width) * constants.Tilesize)
+			x := float64((layer.StartX + i%layer.Width) * constants.Tilesize)
+			y := float64((layer.StartY + i/layer.Width) * constants.Tilesize)
 			if layer.Name == "objects" {
-				objects = append(objects, entities.NewObjects(tileset.Img(tileID), x, y))
+				objects = append(objects, entities.NewObjects(img, x, y, transform))
 				continue
 			}
-			opts.GeoM.Reset()
+			opts.GeoM = transform
 			opts.GeoM.Translate(x, y)
 			opts.GeoM.Translate(camX, camY)
 
-			screen.DrawImage(tileset.Img(int(tileID)), opts)
+			screen.DrawImage(img, opts)
 		}
 	}
 
@@ -122,14 +263,8 @@ func (g *GameScene) Draw(screen *ebiten.Image) {
 		drawables = append(drawables, object)
 	}
 	drawables = append(drawables, g.player)
-	for _, e := range g.enemies {
-		drawables = append(drawables, e)
-	}
-	for _, e := range g.dummies {
-		drawables = append(drawables, e)
-	}
-	for _, p := range g.potions {
-		drawables = append(drawables, p)
+	for _, v := range g.world.SpriteViews() {
+		drawables = append(drawables, v)
 	}
 
 	sort.Slice(drawables, func(i, j int) bool {
@@ -147,47 +282,80 @@ func (g *GameScene) Draw(screen *ebiten.Image) {
 }
 
 func (g *GameScene) Update() SceneId {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	g.input.Update()
+	// Os eventos adiados durante o tick são entregues no fim dele
+	defer g.bus.Flush()
+
+	// Morto não se mexe: fica na tela de game over até reviver
+	if g.PlayerDead() {
+		return GameOverSceneId
+	}
+
+	if g.input.JustPressed(input.Pause) {
 		return PauseSceneId
 	}
+	if g.input.JustPressed(input.Stats) {
+		return StatsSceneId
+	}
+	if g.input.JustPressed(input.QuestLog) {
+		return QuestLogSceneId
+	}
+	if g.input.JustPressed(input.Interact) && g.talk() {
+		return DialogueSceneId
+	}
+	g.hud.Update()
+	g.handleHotbar()
 
 	// 1. Lidar com a entrada e movimento do jogador
 	g.handlePlayerMovement()
+	g.handleThrow()
 
 	g.player.UpdateAttackTick()
 
-	g.player.Move()
-
 	// 2. Atualizar animações
 	activeAnimation := g.player.ActiveAnimation()
 	if activeAnimation != nil {
 		activeAnimation.Update()
 	}
 
-	// 3. Atualizar inimigos
-	g.updateEnemies()
+	// 3. Atualizar inimigos e demais entidades
+	g.updateCombat()
+	g.removeDead()
+	g.updateAI()
+	g.updateRangedAttacks()
+	g.moveEntities()
+	g.animateEntities()
+	g.updatePops()
+	g.updateProjectiles()
 
 	// 4. Lidar com combate
 	g.handleCombat()
+	if g.PlayerDead() {
+		return GameOverSceneId
+	}
 
 	// 5. Lidar com itens coletáveis
 	g.handleCollectibles()
+	g.checkCheckpoints()
 
 	//
 	g.player.UpdateAttack()
 
 	// 6. Checar transições de mapa
 	if nextMap, nextSpawn := g.checkMapTransitions(); nextMap != "" {
+		from := g.currentMap
 		g.LoadMap(nextMap, nextSpawn)
+		events.Publish(g.bus, events.MapChanged{From: from, To: nextMap, Spawn: nextSpawn})
 		// Retornar aqui para o próximo frame começar com o mapa já carregado
 		return GameSceneId
 	}
 
 	// 7. Atualizar a câmera
 	g.Camera.FollowTarget(g.player.X+8, g.player.Y+8, 320, 240)
-	mapWidth := float64(g.TilemapJSON.Layers[0].Width * constants.Tilesize)
-	mapHeight := float64(g.TilemapJSON.Layers[0].Height * constants.Tilesize)
-	g.Camera.Constrain(mapWidth, mapHeight, 320, 240)
+	bounds := g.mapBounds()
+	g.Camera.ConstrainRect(float64(bounds.Min.X), float64(bounds.Min.Y), float64(bounds.Max.X), float64(bounds.Max.Y), 320, 240)
+	// Os efeitos sonoros são ouvidos do meio da tela
+	g.audio.SetListener(-g.Camera.X+320/2, -g.Camera.Y+240/2)
 
 	return GameSceneId
 }
@@ -196,31 +364,34 @@ func (g *GameScene) handlePlayerMovement() {
 	g.player.Dx = 0.0
 	g.player.Dy = 0.0
 
-	if !g.player.IsAttacking() {
-
-		if ebiten.IsKeyPressed(ebiten.KeyW) {
-			g.player.Dy = -2
-		}
-		if ebiten.IsKeyPressed(ebiten.KeyS) {
-			g.player.Dy = 2
-		}
-		if ebiten.IsKeyPressed(ebiten.KeyA) {
-			g.player.Dx = -2
-		}
-		if ebiten.IsKeyPressed(ebiten.KeyD) {
-			g.player.Dx += 2
-		}
+	// Atordoado por um golpe não anda nem ataca
+	stunned := g.player.CombatComp.Stunned()
+	if !g.player.IsAttacking() && !stunned {
+		g.player.Move(g.input.MoveVector())
+	}
+	if kx, ky := g.player.CombatComp.Knockback(); kx != 0 || ky != 0 {
+		g.player.Dx, g.player.Dy = kx, ky
+	} else {
+		// Efeitos como lentidão
+		g.player.Dx *= g.player.CombatComp.SpeedMultiplier()
+		g.player.Dy *= g.player.CombatComp.SpeedMultiplier()
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeySpace) {
-		g.player.Attack()
+	// Clicar golpeia na direção do cursor
+	pointer := g.input.JustPressed(input.PointerAttack) && !stunned
+	if pointer && !g.player.IsAttacking() {
+		cX, cY := g.input.CursorPosition()
+		g.player.FaceTowards(
+			float64(cX)-g.Camera.X-(g.player.X+constants.Tilesize/2),
+			float64(cY)-g.Camera.Y-(g.player.Y+constants.Tilesize/2),
+		)
 	}
 
-	if g.player.Dx != 0 || g.player.Dy != 0 {
-		magnitude := math.Sqrt(g.player.Dx*g.player.Dx + g.player.Dy*g.player.Dy)
-		speed := 2.0
-		g.player.Dx = (g.player.Dx / magnitude) * speed
-		g.player.Dy = (g.player.Dy / magnitude) * speed
+	g.swung = !stunned && (g.input.Pressed(input.Attack) || pointer) && g.player.Attack()
+	if g.swung {
+		g.swingHits = make(map[ecs.Entity]bool)
+		px, py := g.playerCenter()
+		events.Publish(g.bus, events.PlayerAttacked{X: px, Y: py})
 	}
 
 	g.player.X += g.player.Dx
@@ -230,132 +401,201 @@ func (g *GameScene) handlePlayerMovement() {
 	CheckCollisionsVerticaly(g.player.Sprite, g.CollisionGrid)
 }
 
-func (g *GameScene) updateEnemies() {
-	for _, d := range g.dummies {
-		d.Update()
+// Golpes empurram o alvo, deixam ele invulnerável por um tempo e sem
+// poder agir por outro, mais curto.
+const (
+	hitKnockback         = 3.0 // pixels por tick logo depois do golpe
+	hitInvulnerableTicks = 20
+	hitStunTicks         = 15
+
+	playerHitKnockback         = 2.5
+	playerHitInvulnerableTicks = 60
+	playerHitStunTicks         = 10
+)
+
+func (g *GameScene) handleCombat() {
+	w := g.world
+	// Veneno e queimadura também matam
+	alive := !g.PlayerDead()
+	g.player.CombatComp.Update()
+	if alive && g.PlayerDead() {
+		g.playerDied(effectSource)
 	}
 
-	for _, enemy := range g.enemies {
-		enemy.CombatComp.Update()
+	pRect := image.Rect(int(g.player.X), int(g.player.Y), int(g.player.X)+constants.Tilesize, int(g.player.Y)+constants.Tilesize)
 
-		enemy.Dx = 0.0
-		enemy.Dy = 0.0
-		if enemy.FollowsPlayer {
-			// Lógica de perseguição simples
-			if math.Abs(enemy.X-g.player.X) > 1 {
-				if enemy.X < g.player.X {
-					enemy.Dx = 1
-				} else {
-					enemy.Dx = -1
-				}
-			}
-			if math.Abs(enemy.Y-g.player.Y) > 1 {
-				if enemy.Y < g.player.Y {
-					enemy.Dy = 1
-				} else {
-					enemy.Dy = -1
-				}
+	// O golpe acerta o que estiver na frente do jogador, só durante a
+	// parte ativa da animação de ataque
+	hitbox, swinging := g.player.AttackHitbox()
+	fx, fy := g.player.FacingVector()
+
+	for _, e := range w.Query(w.Position, w.Collider) {
+		if !g.targetable(e) {
+			continue
+		}
+		bounds := w.Bounds(e)
+		combat := w.Combat.Get(e)
+
+		// Combate: Inimigo ataca o Jogador (só quando a IA está atacando)
+		if brain := w.AI.Get(e); brain != nil && combat != nil && brain.WantsAttack() && !combat.Stunned() && bounds.Overlaps(pRect) {
+			if !g.player.CombatComp.Invulnerable() && combat.Attack() {
+				damage := combat.AttackDamage()
+				damage.Source = g.sourceName(e)
+				g.hurtPlayer(damage, g.onHitEffects(e), bounds)
 			}
 		}
 
-		enemy.X += enemy.Dx
-		CheckCollisionsHorizontaly(enemy.Sprite, g.CollisionGrid)
-		enemy.Y += enemy.Dy
-		CheckCollisionsVerticaly(enemy.Sprite, g.CollisionGrid)
+		// Combate: Jogador ataca a entidade
+		if swinging && !g.swingHits[e] && bounds.Overlaps(hitbox) {
+			g.swingHits[e] = true
+			damage := g.player.CombatComp.AttackDamage()
+			damage.Source = playerSource
+			g.hitEntity(e, damage, nil, fx, fy)
+		}
 	}
 }
 
-func (g *GameScene) handleCombat() {
-	clicked := inpututil.IsMouseButtonJustPressed(ebiten.MouseButton0)
-	g.player.CombatComp.Update()
+// hitEntity aplica um golpe do jogador (espada ou shuriken) numa entidade,
+// empurrando-a na direção (dirX, dirY).
+func (g *GameScene) hitEntity(e ecs.Entity, damage components.DamageInfo, effects []components.EffectDef, dirX, dirY float64) {
+	w := g.world
+	if flinch := w.Flinch.Get(e); flinch != nil {
+		flinch.Hit()
+		x, y := g.entityCenter(e)
+		events.Defer(g.bus, events.DummyHit{Archetype: g.archetypeName(e), X: x, Y: y})
+	}
 
-	deadEnemies := map[int]struct{}{}
-	pRect := image.Rect(int(g.player.X), int(g.player.Y), int(g.player.X)+constants.Tilesize, int(g.player.Y)+constants.Tilesize)
+	combat := w.Combat.Get(e)
+	if combat == nil {
+		return
+	}
+	if length := math.Hypot(dirX, dirY); length > 0 {
+		dirX, dirY = dirX/length, dirY/length
+	}
+	result, hit := combat.Hit(components.Impact{
+		Damage:            damage,
+		KnockbackX:        dirX * hitKnockback,
+		KnockbackY:        dirY * hitKnockback,
+		InvulnerableTicks: hitInvulnerableTicks,
+		StunTicks:         hitStunTicks,
+		Effects:           effects,
+		Rand:              g.rng,
+	})
+	if !hit {
+		return
+	}
+	x, y := g.entityCenter(e)
+	events.Defer(g.bus, events.EnemyDamaged{Archetype: g.archetypeName(e), Damage: result, Health: combat.Health(), X: x, Y: y})
+	g.killIfDead(e)
+}
 
-	for _, d := range g.dummies {
-		if clicked {
-			cX, cY := ebiten.CursorPosition()
-			worldX, worldY := float64(cX)-g.Camera.X, float64(cY)-g.Camera.Y
-
-			if worldX >= d.X && worldX < d.X+constants.Tilesize && worldY >= d.Y && worldY < d.Y+constants.Tilesize*2 {
-				// Verifica o alcance do ataque
-				distance := math.Sqrt(math.Pow(d.X-g.player.X, 2) + math.Pow(d.Y-g.player.Y, 2))
-				if distance < float64(constants.Tilesize)*2.5 { // Alcance de ataque de 2.5 tiles
-					fmt.Println("Dummy took damage! Health: %d\n")
-					d.Hit()
-				}
-			}
+// killIfDead tira do mundo uma entidade sem vida, seja por um golpe ou
+// por um efeito: avisa da morte, marca o objeto do mapa como removido e
+// sorteia os drops. Retorna true se a entidade morreu.
+func (g *GameScene) killIfDead(e ecs.Entity) bool {
+	w := g.world
+	combat := w.Combat.Get(e)
+	if combat == nil || combat.Health() > 0 {
+		return false
+	}
+	x, y := g.entityCenter(e)
+	died := events.EnemyDied{Archetype: g.archetypeName(e), X: x, Y: y}
+	if origin := w.Origin.Get(e); origin != nil {
+		g.markRemoved(origin.ObjectID)
+		died.ObjectID = origin.ObjectID
+	}
+	events.Defer(g.bus, died)
+	g.dropLoot(e)
+	w.Despawn(e)
+	return true
+}
+
+// hurtPlayer aplica um golpe no jogador, empurrando-o para longe de from
+// (a área de quem bateu).
+func (g *GameScene) hurtPlayer(damage components.DamageInfo, effects []components.EffectDef, from image.Rectangle) {
+	dx := g.player.X + constants.Tilesize/2 - float64(from.Min.X+from.Max.X)/2
+	dy := g.player.Y + constants.Tilesize/2 - float64(from.Min.Y+from.Max.Y)/2
+	if length := math.Hypot(dx, dy); length > 0 {
+		dx, dy = dx/length, dy/length
+	}
+
+	result, hit := g.player.CombatComp.Hit(components.Impact{
+		Damage:            damage,
+		KnockbackX:        dx * playerHitKnockback,
+		KnockbackY:        dy * playerHitKnockback,
+		InvulnerableTicks: playerHitInvulnerableTicks,
+		StunTicks:         playerHitStunTicks,
+		Effects:           effects,
+		Rand:              g.rng,
+	})
+	if hit {
+		px, py := g.playerCenter()
+		events.Defer(g.bus, events.PlayerDamaged{Source: damage.Source, Damage: result, Health: g.player.CombatComp.Health(), X: px, Y: py})
+		if g.PlayerDead() {
+			g.playerDied(damage.Source)
 		}
 	}
+}
 
-	for idx, enemy := range g.enemies {
-		enemyRect := image.Rect(int(enemy.X), int(enemy.Y), int(enemy.X)+constants.Tilesize, int(enemy.Y)+constants.Tilesize)
+// playerDied avisa que a vida do jogador chegou a zero por causa de
+// source.
+func (g *GameScene) playerDied(source string) {
+	px, py := g.playerCenter()
+	events.Defer(g.bus, events.PlayerDied{Source: source, X: px, Y: py})
+}
 
-		// Combate: Inimigo ataca o Jogador
-		if enemyRect.Overlaps(pRect) {
-			if enemy.CombatComp.Attack() {
-				g.player.CombatComp.Damage(enemy.CombatComp.AttackPower())
-				fmt.Printf("Player took damage! Health: %d\n", g.player.CombatComp.Health())
-				if g.player.CombatComp.Health() <= 0 {
-					fmt.Println("PLAYER DIED! Game Over.")
-					// TODO: Implementar lógica de Game Over (ex: ir para uma cena de Game Over)
+// effectSource é o DamageInfo.Source do dano dos efeitos de status.
+const effectSource = "effect"
 
-				}
-			}
-		}
+// playerSource é o DamageInfo.Source dos ataques do jogador.
+const playerSource = "player"
 
-		// Combate: Jogador ataca o Inimigo
-		if clicked {
-			cX, cY := ebiten.CursorPosition()
-			worldX, worldY := float64(cX)-g.Camera.X, float64(cY)-g.Camera.Y
-
-			// Verifica se o clique foi no inimigo
-			if worldX >= enemy.X && worldX < enemy.X+constants.Tilesize && worldY >= enemy.Y && worldY < enemy.Y+constants.Tilesize {
-				// Verifica o alcance do ataque
-				distance := math.Sqrt(math.Pow(enemy.X-g.player.X, 2) + math.Pow(enemy.Y-g.player.Y, 2))
-				if distance < float64(constants.Tilesize)*2.5 { // Alcance de ataque de 2.5 tiles
-					enemy.CombatComp.Damage(g.player.CombatComp.AttackPower())
-					fmt.Printf("Enemy took damage! Health: %d\n", enemy.CombatComp.Health())
-					if enemy.CombatComp.Health() <= 0 {
-						deadEnemies[idx] = struct{}{}
-					}
-				}
-			}
-		}
+// onHitEffects são os efeitos do ataque normal de uma entidade.
+func (g *GameScene) onHitEffects(e ecs.Entity) []components.EffectDef {
+	origin := g.world.Origin.Get(e)
+	if origin == nil || origin.Archetype == nil || origin.Archetype.Combat == nil {
+		return nil
 	}
+	return archetypes.Effects(origin.Archetype.Combat.OnHit)
+}
 
-	// Remove inimigos mortos
-	if len(deadEnemies) > 0 {
-		newEnemies := make([]*entities.Enemy, 0, len(g.enemies)-len(deadEnemies))
-		for i, enemy := range g.enemies {
-			if _, isDead := deadEnemies[i]; !isDead {
-				newEnemies = append(newEnemies, enemy)
-			}
-		}
-		g.enemies = newEnemies
+// sourceName é o nome usado como DamageInfo.Source para uma entidade.
+func (g *GameScene) sourceName(e ecs.Entity) string {
+	if origin := g.world.Origin.Get(e); origin != nil && origin.Archetype != nil {
+		return origin.Archetype.Name
 	}
+	return "unknown"
 }
 
 func (g *GameScene) handleCollectibles() {
-	var potionsToCollect []int
+	w := g.world
 	pRect := image.Rect(int(g.player.X), int(g.player.Y), int(g.player.X)+constants.Tilesize, int(g.player.Y)+constants.Tilesize)
 
-	for i, potion := range g.potions {
-		potionRect := image.Rect(int(potion.X), int(potion.Y), int(potion.X)+constants.Tilesize, int(potion.Y)+constants.Tilesize)
-		if pRect.Overlaps(potionRect) {
-			if g.player.CombatComp.Health() < g.player.CombatComp.MaxHealth() {
-				g.player.CombatComp.Heal(int(potion.AmtHeal))
-				fmt.Printf("Player healed! Current Health: %d\n", g.player.CombatComp.Health())
-				potionsToCollect = append(potionsToCollect, i)
+	for _, e := range w.Query(w.Pickup, w.Position, w.Collider) {
+		// Drops ainda no ar não podem ser pegos
+		if w.Pop.Has(e) || !pRect.Overlaps(w.Bounds(e)) {
+			continue
+		}
+		pickup := w.Pickup.Get(e)
+		if pickup.Item != nil {
+			// Fica no chão o que não coube na bolsa
+			if !g.collectItem(pickup) {
+				continue
 			}
+		} else if g.player.CombatComp.Health() < g.player.CombatComp.MaxHealth() || len(pickup.Effects) > 0 {
+			g.player.CombatComp.Heal(pickup.Heal)
+			for _, effect := range pickup.Effects {
+				g.player.CombatComp.ApplyEffect(effect)
+			}
+			events.Defer(g.bus, events.PlayerHealed{Amount: pickup.Heal, Health: g.player.CombatComp.Health()})
+		} else {
+			// Itens só de cura ficam no chão enquanto a vida está cheia
+			continue
 		}
-	}
-
-	if len(potionsToCollect) > 0 {
-		sort.Sort(sort.Reverse(sort.IntSlice(potionsToCollect)))
-		for _, index := range potionsToCollect {
-			g.potions = append(g.potions[:index], g.potions[index+1:]...)
+		if origin := w.Origin.Get(e); origin != nil {
+			g.markRemoved(origin.ObjectID)
 		}
+		w.Despawn(e)
 	}
 }
 
@@ -380,7 +620,8 @@ func (g *GameScene) checkMapTransitions() (string, string) {
 						}
 
 						if targetMap != "" {
-							return "assets/maps/" + targetMap, targetSpawn
+							// O caminho no Tiled é relativo ao mapa atual
+							return filepath.Join(filepath.Dir(g.currentMap), targetMap), targetSpawn
 						}
 					}
 				}
@@ -391,37 +632,50 @@ func (g *GameScene) checkMapTransitions() (string, string) {
 }
 
 func CheckCollisionsVerticaly(sprite *entities.Sprite, grid *collisions.Grid) {
-	spriteBounds := image.Rect(int(sprite.X), int(sprite.Y), int(sprite.X)+constants.Tilesize, int(sprite.Y)+constants.Tilesize)
+	sprite.Y = collideVertically(sprite.X, sprite.Y, sprite.Dy, constants.Tilesize, constants.Tilesize, grid)
+}
+
+func CheckCollisionsHorizontaly(sprite *entities.Sprite, grid *collisions.Grid) {
+	sprite.X = collideHorizontally(sprite.X, sprite.Y, sprite.Dx, constants.Tilesize, constants.Tilesize, grid)
+}
+
+// collideVertically retorna o novo y de uma caixa w x h em (x, y) que
+// andou dy, encostada no colisor em que entrou.
+func collideVertically(x, y, dy, w, h float64, grid *collisions.Grid) float64 {
+	bounds := image.Rect(int(x), int(y), int(x+w), int(y+h))
 
 	// Pega APENAS os colisores próximos!
-	nearbyColliders := grid.GetNearbyColliders(spriteBounds)
+	nearbyColliders := grid.GetNearbyColliders(bounds)
 
 	for _, collider := range nearbyColliders { // Itera sobre a lista menor
-		if collider.Overlaps(spriteBounds) {
-			if sprite.Dy > 0.0 {
-				sprite.Y = float64(collider.Min.Y) - constants.Tilesize
-			} else if sprite.Dy < 0.0 {
-				sprite.Y = float64(collider.Max.Y)
+		if collider.Overlaps(bounds) {
+			if dy > 0.0 {
+				y = float64(collider.Min.Y) - h
+			} else if dy < 0.0 {
+				y = float64(collider.Max.Y)
 			}
 		}
 	}
+	return y
 }
 
-func CheckCollisionsHorizontaly(sprite *entities.Sprite, grid *collisions.Grid) {
-	spriteBounds := image.Rect(int(sprite.X), int(sprite.Y), int(sprite.X)+constants.Tilesize, int(sprite.Y)+constants.Tilesize)
+// collideHorizontally é o mesmo que collideVertically para o eixo x.
+func collideHorizontally(x, y, dx, w, h float64, grid *collisions.Grid) float64 {
+	bounds := image.Rect(int(x), int(y), int(x+w), int(y+h))
 
 	// Pega APENAS os colisores próximos!
-	nearbyColliders := grid.GetNearbyColliders(spriteBounds)
+	nearbyColliders := grid.GetNearbyColliders(bounds)
 
 	for _, collider := range nearbyColliders { // Itera sobre a lista menor
-		if collider.Overlaps(spriteBounds) {
-			if sprite.Dx > 0.0 {
-				sprite.X = float64(collider.Min.X) - constants.Tilesize
-			} else if sprite.Dx < 0.0 {
-				sprite.X = float64(collider.Max.X)
+		if collider.Overlaps(bounds) {
+			if dx > 0.0 {
+				x = float64(collider.Min.X) - w
+			} else if dx < 0.0 {
+				x = float64(collider.Max.X)
 			}
 		}
 	}
+	return x
 }
 
 func (g *GameScene) IsLoaded() bool {
@@ -437,5 +691,14 @@ func (g *GameScene) findTilesetForTile(tileID int) *tileset.Tileset {
 	return nil
 }
 
-func (g *GameScene) OnEnter() {}
-func (g *GameScene) OnExit()  {}
+// OnEnter liga os sons aos eventos; com a cena fora da tela (pausa,
+// diálogo...) eles ficam desligados. O botão que fechou o menu não vale
+// no jogo até ser solto.
+func (g *GameScene) OnEnter() {
+	g.input.WaitForRelease()
+	g.subscribeSounds()
+}
+
+func (g *GameScene) OnExit() {
+	g.sceneScope.Close()
+}
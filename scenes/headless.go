@@ -0,0 +1,83 @@
+package scenes
+
+import (
+	"math/rand/v2"
+	"rpg-go/camera"
+	"rpg-go/entities"
+	"rpg-go/input"
+	"rpg-go/quests"
+	"rpg-go/spritesheet"
+)
+
+// NewHeadlessGameScene cria uma GameScene que roda sem janela: nenhuma imagem
+// é carregada e a entrada vem de src (normalmente um input.ScriptedSource),
+// lida com as bindings padrão. As definições vêm de dirs e o som fica no
+// NullManager.
+// Serve para simular a jogabilidade em testes, chamando Step e inspecionando
+// o estado depois.
+func NewHeadlessGameScene(mapPath, spawn string, dirs DataDirs, src input.Source) (*GameScene, error) {
+	g := NewGameScene(input.NewHandler(src, input.DefaultBindings()))
+	g.headless = true
+
+	g.assets = spritesheet.NewHeadlessAssets()
+	g.Camera = camera.NewCamera(0, 0)
+
+	if err := g.loadData(dirs); err != nil {
+		return nil, err
+	}
+	if err := g.startRun(mapPath, spawn); err != nil {
+		return nil, err
+	}
+	g.loaded = true
+	g.OnEnter()
+	return g, nil
+}
+
+// SetSeed fixa a semente dos sorteios de drops e críticos, para que uma
+// simulação dê sempre o mesmo resultado.
+func (g *GameScene) SetSeed(seed uint64) {
+	g.rng = rand.New(rand.NewPCG(seed, seed))
+}
+
+// Step executa N ticks de Update. Para antes se a cena pedir para trocar
+// para outra cena (ex: pausa) e retorna o id da cena pedida.
+func (g *GameScene) Step(ticks int) SceneId {
+	for i := 0; i < ticks; i++ {
+		if next := g.Update(); next != GameSceneId {
+			return next
+		}
+	}
+	return GameSceneId
+}
+
+func (g *GameScene) PlayerPosition() (float64, float64) {
+	return g.player.X, g.player.Y
+}
+
+func (g *GameScene) PlayerHealth() int {
+	return g.player.CombatComp.Health()
+}
+
+func (g *GameScene) EnemiesAlive() int {
+	return g.world.Combat.Len()
+}
+
+func (g *GameScene) PotionsLeft() int {
+	return g.world.Pickup.Len()
+}
+
+func (g *GameScene) CurrentMap() string {
+	return g.currentMap
+}
+
+// Player expõe o jogador para que simulações possam posicioná-lo
+// diretamente (ex: em cima de uma poção ou de uma transição).
+func (g *GameScene) Player() *entities.Player {
+	return g.player
+}
+
+// QuestLog expõe o diário de missões, para simulações conferirem o
+// progresso.
+func (g *GameScene) QuestLog() *quests.Log {
+	return g.questLog
+}
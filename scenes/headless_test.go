@@ -0,0 +1,180 @@
+package scenes_test
+
+import (
+	"errors"
+	"testing"
+
+	"rpg-go/components"
+	"rpg-go/events"
+	"rpg-go/input"
+	"rpg-go/scenes"
+	"rpg-go/sound"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Os testes rodam de dentro de scenes/, então os assets ficam um nível
+// acima.
+const testMap = "../assets/maps/spawn.json"
+
+func testDataDirs() scenes.DataDirs {
+	return scenes.DataDirs{
+		Entities: "../assets/entities",
+		Items:    "../assets/items",
+		Loot:     "../assets/loot",
+		Dialogue: "../assets/dialogue",
+		Quests:   "../assets/quests",
+	}
+}
+
+func newTestScene(t *testing.T, spawn string, src input.Source) *scenes.GameScene {
+	t.Helper()
+	g, err := scenes.NewHeadlessGameScene(testMap, spawn, testDataDirs(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.SetSeed(1)
+	return g
+}
+
+func TestHeadlessMissingMap(t *testing.T) {
+	if _, err := scenes.NewHeadlessGameScene("../assets/maps/nada.json", "default", testDataDirs(), input.NewScriptedSource()); err == nil {
+		t.Fatal("esperava erro com o mapa inexistente")
+	}
+}
+
+func TestCombatEnemyHurtsPlayer(t *testing.T) {
+	src := input.NewScriptedSource()
+	g := newTestScene(t, "default", src)
+	start := g.PlayerHealth()
+
+	// Parado em cima do esqueleto 67, que persegue o jogador
+	g.Player().X, g.Player().Y = 62, 139
+	src.Idle(120)
+	g.Step(120)
+
+	if g.PlayerHealth() >= start {
+		t.Fatalf("vida do jogador = %d, esperava menos que %d", g.PlayerHealth(), start)
+	}
+}
+
+func TestCombatPlayerKillsEnemy(t *testing.T) {
+	src := input.NewScriptedSource()
+	g := newTestScene(t, "default", src)
+	start := g.EnemiesAlive()
+
+	// Ao lado do esqueleto 67, virado para ele, golpeando sem parar
+	g.Player().X, g.Player().Y = 76, 139
+	src.Hold(input.Frame{Keys: []ebiten.Key{ebiten.KeyA}}, 1)
+	src.Hold(input.Frame{Keys: []ebiten.Key{ebiten.KeySpace}}, 300)
+	g.Step(301)
+
+	if g.EnemiesAlive() >= start {
+		t.Fatalf("inimigos vivos = %d, esperava menos que %d", g.EnemiesAlive(), start)
+	}
+}
+
+func TestCollectPotion(t *testing.T) {
+	src := input.NewScriptedSource()
+	g := newTestScene(t, "default", src)
+	start, carried := g.PotionsLeft(), g.Player().Inventory.Count("potion")
+
+	// Anda para a direita até a poção 77
+	g.Player().X, g.Player().Y = 240, 211
+	src.Hold(input.Frame{Keys: []ebiten.Key{ebiten.KeyD}}, 30)
+	g.Step(30)
+
+	if g.PotionsLeft() != start-1 {
+		t.Fatalf("poções no mapa = %d, esperava %d", g.PotionsLeft(), start-1)
+	}
+	if g.Player().Inventory.Count("potion") <= carried {
+		t.Fatal("a poção não foi para a bolsa")
+	}
+}
+
+func TestMapTransition(t *testing.T) {
+	src := input.NewScriptedSource()
+	// O spawn "door" fica logo abaixo da porta do dojo
+	g := newTestScene(t, "door", src)
+
+	src.Hold(input.Frame{Keys: []ebiten.Key{ebiten.KeyW}}, 30)
+	g.Step(30)
+
+	if want := "../assets/maps/dojo.json"; g.CurrentMap() != want {
+		t.Fatalf("mapa atual = %q, esperava %q", g.CurrentMap(), want)
+	}
+}
+
+func TestRestoreMissingMapKeepsGame(t *testing.T) {
+	g := newTestScene(t, "default", input.NewScriptedSource())
+	data := g.Snapshot()
+	data.Map = "../assets/maps/nada.json"
+	data.Flags = []string{"do_save"}
+	enemies := g.EnemiesAlive()
+
+	err := g.Restore(data)
+	if !errors.Is(err, scenes.ErrSavedMap) {
+		t.Fatalf("erro = %v, esperava ErrSavedMap", err)
+	}
+	if g.CurrentMap() != testMap || g.EnemiesAlive() != enemies || g.Flag("do_save") {
+		t.Fatal("o jogo atual mudou com um save que não carregou")
+	}
+}
+
+func TestRestoreClearsPlayerState(t *testing.T) {
+	g := newTestScene(t, "default", input.NewScriptedSource())
+	data := g.Snapshot()
+	g.Player().CombatComp.ApplyEffect(components.StandardEffects[components.EffectPoison])
+	g.Player().Attack()
+
+	if err := g.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Player().CombatComp.Effects()) > 0 || g.Player().IsAttacking() {
+		t.Fatal("o jogador carregado manteve efeitos ou o golpe do jogo anterior")
+	}
+}
+
+func TestSoundsOnlyWhileSceneActive(t *testing.T) {
+	g := newTestScene(t, "default", input.NewScriptedSource())
+	backend := &sound.NullBackend{}
+	config := sound.DefaultConfig()
+	config.Sounds["attack"] = "attack.ogg"
+	g.SetAudio(sound.NewManager(backend, config))
+	x, y := g.PlayerPosition()
+	g.Audio().SetListener(x, y)
+
+	events.Publish(g.Events(), events.PlayerAttacked{X: x, Y: y})
+	g.OnExit()
+	events.Publish(g.Events(), events.PlayerAttacked{X: x, Y: y})
+	g.OnEnter()
+	events.Publish(g.Events(), events.PlayerAttacked{X: x, Y: y})
+
+	if len(backend.Played) != 2 {
+		t.Fatalf("sons tocados = %v, esperava 2 (com a cena ativa)", backend.Played)
+	}
+}
+
+func TestMenuButtonDoesNotAttack(t *testing.T) {
+	src := input.NewScriptedSource()
+	g := newTestScene(t, "default", src)
+	confirm := input.Frame{GamepadButtons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}}
+
+	// O A do controle confirma no menu e continua apertado na volta ao jogo
+	src.Hold(confirm, 6)
+	g.OnExit()
+	src.Update()
+	g.OnEnter()
+	g.Step(5)
+	if g.Player().IsAttacking() {
+		t.Fatal("o botão que fechou o menu virou um golpe")
+	}
+
+	// Solto e apertado de novo, ataca
+	src.Idle(1)
+	src.Hold(confirm, 1)
+	g.Step(2)
+	if !g.Player().IsAttacking() {
+		t.Fatal("o botão de ataque não funcionou depois de solto")
+	}
+}
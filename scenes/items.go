@@ -0,0 +1,220 @@
+package scenes
+
+import (
+	"log"
+	"maps"
+	"rpg-go/archetypes"
+	"rpg-go/ecs"
+	"rpg-go/events"
+	"rpg-go/input"
+	"rpg-go/inventory"
+	"rpg-go/save"
+	"rpg-go/tilemap"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// hotbarActions são as ações que usam cada atalho da hotbar.
+var hotbarActions = [inventory.HotbarSize]input.Action{
+	input.Hotbar1, input.Hotbar2, input.Hotbar3, input.Hotbar4,
+}
+
+// pickupItem resolve o item guardado por um coletável: o da propriedade
+// "item" do objeto do Tiled, se houver, senão o da definição.
+func (g *GameScene) pickupItem(def *archetypes.Archetype, properties []tilemap.TiledProperty) (*inventory.Item, int) {
+	name := def.Pickup.Item
+	if requested, ok := tilemap.GetStringProperty("item", properties); ok && requested != "" {
+		name = requested
+	}
+	if name == "" {
+		return nil, 0
+	}
+	item, ok := g.items.Get(name)
+	if !ok {
+		log.Printf("Aviso: item '%s' de '%s' não existe.", name, def.Name)
+		return nil, 0
+	}
+	return item, max(def.Pickup.Count, 1)
+}
+
+// collectItem guarda na bolsa o item de um coletável. Retorna true se
+// tudo coube; senão o resto fica no chão.
+func (g *GameScene) collectItem(pickup *ecs.Pickup) bool {
+	added := g.player.Inventory.Add(pickup.Item, pickup.Count)
+	if added == 0 {
+		return false
+	}
+	pickup.Count -= added
+	px, py := g.playerCenter()
+	events.Defer(g.bus, events.ItemCollected{
+		Item:  pickup.Item.Name,
+		Title: pickup.Item.Title,
+		Count: added,
+		Total: g.player.Inventory.Count(pickup.Item.Name),
+		X:     px,
+		Y:     py,
+	})
+	return pickup.Count == 0
+}
+
+func (g *GameScene) handleHotbar() {
+	for i, action := range hotbarActions {
+		if !g.input.JustPressed(action) {
+			continue
+		}
+		name := g.player.Inventory.Hotbar(i)
+		if item, ok := g.player.Inventory.Find(name); ok {
+			g.useItem(item)
+		}
+	}
+}
+
+// useItem usa um consumível da bolsa. Poções só de cura não são gastas
+// com a vida cheia.
+func (g *GameScene) useItem(item *inventory.Item) bool {
+	if !item.Usable() || g.player.Inventory.Count(item.Name) == 0 {
+		return false
+	}
+	combat := g.player.CombatComp
+	use := item.Use
+	if use.Heal > 0 && len(use.Effects) == 0 && combat.Health() >= combat.MaxHealth() {
+		events.Publish(g.bus, events.ItemUseRejected{Item: item.Name, Title: item.Title, Reason: "already at full health"})
+		return false
+	}
+
+	combat.Heal(use.Heal)
+	for _, effect := range archetypes.Effects(use.Effects) {
+		combat.ApplyEffect(effect)
+	}
+	g.player.Inventory.Remove(item.Name, 1)
+	events.Publish(g.bus, events.ItemUsed{Item: item.Name, Title: item.Title, Health: combat.Health()})
+	return true
+}
+
+// itemSprite é o sprite de um item no chão: o frame do ícone.
+func (g *GameScene) itemSprite(item *inventory.Item) *ecs.Sprite {
+	img, err := g.assets.Image(item.Icon.Image)
+	if err != nil {
+		log.Printf("Aviso: item '%s': %v", item.Name, err)
+	}
+	return &ecs.Sprite{
+		Img:         img,
+		FrameWidth:  item.Icon.FrameWidth,
+		FrameHeight: item.Icon.FrameHeight,
+		Columns:     item.Icon.Columns,
+		Frame:       item.Icon.Frame,
+	}
+}
+
+// itemIcon é o frame do ícone de um item (nil sem janela ou sem imagem).
+func (g *GameScene) itemIcon(item *inventory.Item) *ebiten.Image {
+	img, err := g.assets.Image(item.Icon.Image)
+	if err != nil {
+		log.Printf("Aviso: item '%s': %v", item.Name, err)
+		return nil
+	}
+	if img == nil {
+		return nil
+	}
+	return img.SubImage(item.Icon.Rect()).(*ebiten.Image)
+}
+
+// snapshotInventory copia a bolsa, a hotbar e os equipamentos do jogador
+// para o save.
+func (g *GameScene) snapshotInventory(state *save.PlayerState) {
+	inv := g.player.Inventory
+	for _, slot := range inv.Slots() {
+		stack := save.ItemStack{}
+		if slot.Item != nil {
+			stack = save.ItemStack{Item: slot.Item.Name, Count: slot.Count}
+		}
+		state.Inventory = append(state.Inventory, stack)
+	}
+	for i := 0; i < inventory.HotbarSize; i++ {
+		state.Hotbar = append(state.Hotbar, inv.Hotbar(i))
+	}
+	state.Currency = maps.Clone(inv.Currency())
+
+	for _, slot := range inventory.EquipSlots {
+		if item := g.player.Equipment.Get(slot); item != nil {
+			if state.Equipment == nil {
+				state.Equipment = make(map[string]string)
+			}
+			state.Equipment[string(slot)] = item.Name
+		}
+	}
+}
+
+// restoreInventory refaz a bolsa e os equipamentos a partir do save. Itens
+// que não existem mais são descartados com um aviso.
+func (g *GameScene) restoreInventory(state save.PlayerState) {
+	inv := g.player.Inventory
+	inv.Clear()
+	for _, stack := range state.Inventory {
+		if stack.Item == "" {
+			continue
+		}
+		item, ok := g.items.Get(stack.Item)
+		if !ok {
+			log.Printf("Aviso: o save tem o item '%s', que não existe mais.", stack.Item)
+			continue
+		}
+		inv.Add(item, stack.Count)
+	}
+	for name, amount := range state.Currency {
+		if item, ok := g.items.Get(name); ok {
+			inv.Add(item, amount)
+		}
+	}
+	for i, name := range state.Hotbar {
+		inv.SetHotbar(i, name)
+	}
+
+	g.player.Equipment.Clear()
+	for _, name := range state.Equipment {
+		item, ok := g.items.Get(name)
+		if !ok {
+			log.Printf("Aviso: o save tem o item '%s', que não existe mais.", name)
+			continue
+		}
+		if _, err := g.player.Equipment.Equip(item); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// equipItem veste um item da bolsa. O que estava no mesmo espaço volta
+// para a bolsa.
+func (g *GameScene) equipItem(item *inventory.Item) bool {
+	inv, equipment := g.player.Inventory, g.player.Equipment
+	if inv.Remove(item.Name, 1) == 0 {
+		return false
+	}
+	previous, err := equipment.Equip(item)
+	if err != nil {
+		log.Println(err)
+		inv.Add(item, 1)
+		return false
+	}
+	if previous != nil && inv.Add(previous, 1) == 0 {
+		// Sem espaço para o antigo: desfaz a troca
+		equipment.Equip(previous)
+		inv.Add(item, 1)
+		return false
+	}
+	g.applyPlayerStats()
+	events.Publish(g.bus, events.ItemEquipped{Item: item.Name, Title: item.Title, Slot: string(item.Equip.Slot)})
+	return true
+}
+
+// unequipSlot devolve para a bolsa o item vestido num espaço.
+func (g *GameScene) unequipSlot(slot inventory.Slot) bool {
+	item := g.player.Equipment.Get(slot)
+	if item == nil || g.player.Inventory.Add(item, 1) == 0 {
+		return false
+	}
+	g.player.Equipment.Unequip(slot)
+	g.applyPlayerStats()
+	events.Publish(g.bus, events.ItemUnequipped{Item: item.Name, Title: item.Title, Slot: string(slot)})
+	return true
+}
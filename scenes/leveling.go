@@ -0,0 +1,59 @@
+package scenes
+
+import (
+	"rpg-go/components"
+	"rpg-go/entities"
+	"rpg-go/events"
+	"rpg-go/progression"
+)
+
+// SetLevelCurve troca a tabela de níveis (normalmente lida do arquivo de
+// gameplay).
+func (g *GameScene) SetLevelCurve(curve progression.Curve) {
+	g.leveling = curve
+	if g.hud != nil {
+		g.hud.SetLevelCurve(curve)
+	}
+}
+
+// applyPlayerStats recalcula os atributos do jogador a partir do nível
+// atual e do que ele tem vestido. A vida atual só muda se passar da nova
+// vida máxima.
+func (g *GameScene) applyPlayerStats() {
+	stats := g.leveling.Stats(g.player.Progress.Level)
+	mods := g.player.Equipment.Modifiers()
+	combat := g.player.CombatComp
+
+	combat.SetMaxHealth(stats.MaxHealth + mods.MaxHealth)
+	combat.SetHealth(min(combat.Health(), combat.MaxHealth()))
+	combat.SetAttackPower(stats.AttackPower + mods.AttackPower)
+	combat.SetDefense(components.Defense{Armor: mods.Armor, Resistances: mods.Resistances})
+
+	damageType := components.DamagePhysical
+	if weapon := g.player.Equipment.Weapon(); weapon != nil && weapon.DamageType != "" {
+		damageType = weapon.DamageType
+	}
+	combat.SetAttackStyle(damageType, entities.PlayerCritChance+mods.CritChance, components.DefaultCritMultiplier)
+	g.player.SetAttackSpeed(mods.AttackSpeed)
+}
+
+// grantXP dá experiência ao jogador. Ao subir de nível a vida máxima
+// aumenta e a vida atual aumenta o mesmo tanto.
+func (g *GameScene) grantXP(amount int) {
+	if amount <= 0 {
+		return
+	}
+
+	oldMaxHealth := g.player.CombatComp.MaxHealth()
+	gained := g.player.Progress.AddXP(amount, g.leveling)
+	events.Publish(g.bus, events.XPGained{Amount: amount, Total: g.player.Progress.XP})
+	if gained == 0 {
+		return
+	}
+
+	g.applyPlayerStats()
+	if bonus := g.player.CombatComp.MaxHealth() - oldMaxHealth; bonus > 0 {
+		g.player.CombatComp.Heal(bonus)
+	}
+	events.Publish(g.bus, events.LevelUp{Level: g.player.Progress.Level})
+}
@@ -1,68 +1,114 @@
 package scenes
 
 import (
+	"fmt"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"image"
 	"image/color"
 	"log"
+	"path/filepath"
+	"rpg-go/ai"
 	"rpg-go/collisions"
-	"rpg-go/components"
 	"rpg-go/constants"
-	"rpg-go/entities"
+	"rpg-go/pathfinding"
 	"rpg-go/tilemap"
+	"rpg-go/tileset"
 )
 
-// LoadMap limpa o estado do mapa antigo e carrega um novo.
+// LoadMap limpa o estado do mapa antigo e carrega um novo. Encerra o jogo
+// se o mapa não puder ser carregado.
 func (g *GameScene) LoadMap(mapPath string, targetSpawn string) {
-	// Limpa entidades e colisões do mapa anterior
-	g.enemies = make([]*entities.Enemy, 0)
-	g.potions = make([]*entities.Potion, 0)
-	g.dummies = make([]*entities.TrainingDummy, 0)
+	if err := g.loadMap(mapPath, targetSpawn); err != nil {
+		log.Fatal(err)
+	}
+}
 
+// loadMap é o LoadMap que retorna o erro. Tudo que pode falhar é lido
+// antes de mexer no mapa atual, que fica intacto se der erro.
+func (g *GameScene) loadMap(mapPath string, targetSpawn string) error {
 	// Carrega o JSON do novo mapa
-	tilemapJSON, err := tilemap.NewTilemapJSON(mapPath)
+	tilemapJSON, err := tilemap.Load(mapPath)
 	if err != nil {
-		log.Fatalf("Could not load map %s: %v", mapPath, err)
+		return fmt.Errorf("falha ao carregar o mapa %s: %w", mapPath, err)
 	}
-	g.TilemapJSON = tilemapJSON
-
-	// Gera os tilesets para o novo mapa
-	tilesets, err := tilemapJSON.GenTilesets()
+	// Gera os tilesets para o novo mapa (só são usados para desenhar)
+	var tilesets []*tileset.Tileset
+	if !g.headless {
+		if tilesets, err = tilemapJSON.GenTilesets(); err != nil {
+			return fmt.Errorf("mapa %s: %w", mapPath, err)
+		}
+	}
+	tileCollisions, err := tilemapJSON.TileCollisions()
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("mapa %s: %w", mapPath, err)
 	}
+
+	// Limpa entidades e colisões do mapa anterior
+	g.world.Clear()
+	g.checkpointZones = make([]image.Rectangle, 0)
+	g.TilemapJSON = tilemapJSON
+	g.currentMap = mapPath
 	g.Tilesets = tilesets
 
-	mapWidthPixels := g.TilemapJSON.Layers[0].Width * constants.Tilesize
-	mapHeightPixels := g.TilemapJSON.Layers[0].Height * constants.Tilesize
-	g.CollisionGrid = collisions.NewGrid(mapWidthPixels, mapHeightPixels)
+	// A música do mapa vem da propriedade "music", um arquivo relativo ao
+	// mapa. Mapas sem música deixam a anterior sumir.
+	music := ""
+	if file, ok := tilemap.GetStringProperty("music", tilemapJSON.Properties); ok && file != "" {
+		music = filepath.Join(filepath.Dir(mapPath), file)
+	}
+	g.audio.PlayMusic(music)
+
+	g.CollisionGrid = collisions.NewGridRect(g.mapBounds())
 
 	colliderCount := 0
 
 	spawnPoints := make(map[string]image.Point)
 
+	// Índice de todos os objetos, para resolver propriedades do tipo
+	// "object" (ex: a rota de patrulha de um inimigo)
+	objectsByID := make(map[int]tilemap.TiledObject)
 	for _, layer := range g.TilemapJSON.Layers {
-		if layer.Type == "objectgroup" {
-			log.Printf("Processando camada de objetos: '%s'", layer.Name)
-			if layer.Name == "collisions" {
-				for _, col := range layer.Objects {
-					x := col.X
-					y := col.Y
-					w := col.Width
-					h := col.Height
-
-					if col.GID > 0 {
-						y = y - h
-					}
+		for _, obj := range layer.Objects {
+			objectsByID[obj.ID] = obj
+		}
+	}
+
+	// Primeiro os colisores: a grade de navegação precisa de todos eles
+	for _, layer := range g.TilemapJSON.Layers {
+		if layer.Type == "objectgroup" && layer.Name == "collisions" {
+			for _, col := range layer.Objects {
+				x := col.X
+				y := col.Y
+				w := col.Width
+				h := col.Height
 
-					collider := image.Rect(int(x), int(y), int(x+w), int(y+h))
-					g.CollisionGrid.Insert(&collider)
-					colliderCount++
+				if col.GID > 0 {
+					y = y - h
 				}
+
+				collider := image.Rect(int(x), int(y), int(x+w), int(y+h))
+				g.CollisionGrid.Insert(&collider)
+				colliderCount++
 			}
+		}
+	}
+	colliderCount += g.insertTileColliders(tileCollisions)
+	g.pathfinder = pathfinding.NewPathfinder(pathfinding.NewNavGrid(g.CollisionGrid, constants.Tilesize))
+
+	for _, layer := range g.TilemapJSON.Layers {
+		if layer.Type == "objectgroup" && layer.Name != "collisions" {
+			log.Printf("Processando camada de objetos: '%s'", layer.Name)
 			for _, obj := range layer.Objects {
+				// Inimigos mortos e itens coletados não voltam
+				if g.isRemoved(mapPath, obj.ID) {
+					continue
+				}
 				switch obj.Type {
+				case "checkpoint":
+					zone := image.Rect(int(obj.X), int(obj.Y), int(obj.X+obj.Width), int(obj.Y+obj.Height))
+					g.checkpointZones = append(g.checkpointZones, zone)
+
 				case "player_spawn":
 					spawnName := obj.Name
 					if spawnName == "" {
@@ -70,47 +116,14 @@ func (g *GameScene) LoadMap(mapPath string, targetSpawn string) {
 					}
 					spawnPoints[spawnName] = image.Point{X: int(obj.X), Y: int(obj.Y)}
 
-				case "enemy_spawn":
-					follows := false
-					for _, prop := range obj.Properties {
-						if prop.Name == "followsPlayer" {
-							if val, ok := prop.Value.(bool); ok {
-								follows = val
-							}
-						}
-					}
+				case "transition":
+					// Tratado em checkMapTransitions
 
-					newEnemy := &entities.Enemy{
-						Sprite: &entities.Sprite{
-							Img: g.assets.SkeletonImg,
-							X:   obj.X,
-							Y:   obj.Y,
-						},
-						FollowsPlayer: follows,
-						CombatComp:    components.NewEnemieCombat(3, 1, 60), // Cooldown de 1s (60 ticks)
+				default:
+					// Inimigos, itens, bonecos... vêm das definições em assets/entities
+					if obj.Type != "" && !g.spawnObject(obj, objectsByID) {
+						log.Printf("Aviso: nenhum arquétipo cria objetos do tipo '%s' (objeto %d).", obj.Type, obj.ID)
 					}
-					g.enemies = append(g.enemies, newEnemy)
-
-				case "training_dummy":
-
-					newDummy := entities.NewTrainingDummy(obj.X, obj.Y, g.assets.DummyImg)
-					g.dummies = append(g.dummies, newDummy)
-
-				case "potion_spawn":
-
-					amount, found := tilemap.GetIntProperty("amount", obj.Properties)
-					if !found {
-						amount = 2
-					}
-					newPotion := &entities.Potion{
-						Sprite: &entities.Sprite{
-							Img: g.assets.PotionImg,
-							X:   obj.X,
-							Y:   obj.Y,
-						},
-						AmtHeal: uint(amount),
-					}
-					g.potions = append(g.potions, newPotion)
 				}
 			}
 		}
@@ -124,6 +137,98 @@ func (g *GameScene) LoadMap(mapPath string, targetSpawn string) {
 	}
 	g.player.X = float64(spawnPos.X)
 	g.player.Y = float64(spawnPos.Y)
+	g.entryPoint = respawnPoint{Map: mapPath, X: g.player.X, Y: g.player.Y}
+	return nil
+}
+
+// insertTileColliders põe na grade os colisores desenhados nos tiles do
+// tileset, espelhados/girados junto com cada tile. Retorna quantos foram.
+func (g *GameScene) insertTileColliders(tileCollisions map[int]tilemap.TileCollision) int {
+	if len(tileCollisions) == 0 {
+		return 0
+	}
+
+	count := 0
+	for _, layer := range g.TilemapJSON.Layers {
+		if layer.Type != "tilelayer" {
+			continue
+		}
+		for i, gid := range layer.Data {
+			tile := tilemap.DecodeGID(gid)
+			collision, ok := tileCollisions[tile.ID]
+			if !ok {
+				continue
+			}
+			cell := image.Pt(layer.StartX+i%layer.Width, layer.StartY+i/layer.Width).Mul(constants.Tilesize)
+			for _, shape := range collision.Shapes {
+				collider := tile.TransformRect(shape, collision.Width, collision.Height).Add(cell)
+				g.CollisionGrid.Insert(&collider)
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// mapBounds é a área do mapa em pixels. Mapas infinitos podem começar em
+// coordenadas negativas.
+func (g *GameScene) mapBounds() image.Rectangle {
+	tiles := g.TilemapJSON.Bounds()
+	return image.Rectangle{Min: tiles.Min.Mul(constants.Tilesize), Max: tiles.Max.Mul(constants.Tilesize)}
+}
+
+// enemyAIParams aplica as propriedades de IA de um enemy_spawn sobre os
+// parâmetros da definição do inimigo.
+func enemyAIParams(params ai.Params, obj tilemap.TiledObject, objectsByID map[int]tilemap.TiledObject) ai.Params {
+	if follows, ok := tilemap.GetBoolProperty("follows_player", obj.Properties); ok {
+		params.Chases = follows
+	} else if follows, ok := tilemap.GetBoolProperty("followsPlayer", obj.Properties); ok {
+		params.Chases = follows
+	}
+	if v, ok := tilemap.GetFloatProperty("aggro_radius", obj.Properties); ok {
+		params.AggroRadius = v
+	}
+	if v, ok := tilemap.GetFloatProperty("attack_range", obj.Properties); ok {
+		params.AttackRange = v
+	}
+	if v, ok := tilemap.GetFloatProperty("leash_radius", obj.Properties); ok {
+		params.LeashRadius = v
+	}
+	if v, ok := tilemap.GetFloatProperty("speed", obj.Properties); ok {
+		params.Speed = v
+	}
+	if v, ok := tilemap.GetIntProperty("flee_health_percent", obj.Properties); ok {
+		params.FleeHealthPercent = v
+	}
+	if v, ok := tilemap.GetIntProperty("idle_ticks", obj.Properties); ok {
+		params.IdleTicks = v
+	}
+	if v, ok := tilemap.GetIntProperty("memory_ticks", obj.Properties); ok {
+		params.MemoryTicks = v
+	}
+
+	// "patrol" aponta para um objeto polilinha (vai e volta) ou polígono (circuito)
+	if id, ok := tilemap.GetIntProperty("patrol", obj.Properties); ok {
+		route, found := objectsByID[id]
+		switch {
+		case !found:
+			log.Printf("Aviso: rota de patrulha %d do inimigo %d não existe.", id, obj.ID)
+		case len(route.Polygon) > 0:
+			params.Patrol = routePoints(route, route.Polygon)
+			params.PatrolLoop = true
+		default:
+			params.Patrol = routePoints(route, route.Polyline)
+		}
+	}
+	return params
+}
+
+func routePoints(route tilemap.TiledObject, points []tilemap.TiledPoint) []ai.Point {
+	result := make([]ai.Point, 0, len(points))
+	for _, p := range points {
+		result = append(result, ai.Point{X: route.X + p.X, Y: route.Y + p.Y})
+	}
+	return result
 }
 
 func (g *GameScene) debugDrawColliders(screen *ebiten.Image) {
@@ -0,0 +1,54 @@
+package scenes
+
+import (
+	"rpg-go/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// menuItem é uma opção de menu. onSelect retorna a cena para onde ir.
+type menuItem struct {
+	label    string
+	onSelect func() SceneId
+}
+
+// menu é uma lista vertical de opções navegável por teclado, D-pad
+// ou qualquer outra binding de MoveUp/MoveDown.
+type menu struct {
+	items    []menuItem
+	selected int
+}
+
+func newMenu(items ...menuItem) *menu {
+	return &menu{items: items}
+}
+
+// Update move a seleção e, se a opção for confirmada, retorna a cena
+// escolhida e true.
+func (m *menu) Update(in *input.Handler) (SceneId, bool) {
+	if len(m.items) == 0 {
+		return 0, false
+	}
+	if in.JustPressed(input.MoveUp) {
+		m.selected = (m.selected - 1 + len(m.items)) % len(m.items)
+	}
+	if in.JustPressed(input.MoveDown) {
+		m.selected = (m.selected + 1) % len(m.items)
+	}
+	if in.JustPressed(input.Confirm) {
+		return m.items[m.selected].onSelect(), true
+	}
+	return 0, false
+}
+
+// Draw desenha as opções a partir de (x, y), marcando a selecionada.
+func (m *menu) Draw(screen *ebiten.Image, x, y int) {
+	for i, item := range m.items {
+		label := "  " + item.label
+		if i == m.selected {
+			label = "> " + item.label
+		}
+		ebitenutil.DebugPrintAt(screen, label, x, y+i*16)
+	}
+}
@@ -0,0 +1,118 @@
+package scenes
+
+import (
+	"image"
+	"log"
+	"rpg-go/constants"
+	"rpg-go/dialogue"
+	"rpg-go/ecs"
+	"rpg-go/events"
+)
+
+// talkRange é quantos pixels em volta do jogador alcançam um NPC.
+const talkRange = 8
+
+// talk começa a conversa com o NPC perto do jogador. Retorna false se não
+// há ninguém para conversar.
+func (g *GameScene) talk() bool {
+	w := g.world
+	reach := image.Rect(int(g.player.X), int(g.player.Y), int(g.player.X)+constants.Tilesize, int(g.player.Y)+constants.Tilesize).
+		Inset(-talkRange)
+
+	for _, e := range w.Query(w.NPC, w.Position, w.Collider) {
+		if !reach.Overlaps(w.Bounds(e)) {
+			continue
+		}
+		npc := w.NPC.Get(e)
+		tree, ok := g.dialogues.Get(npc.Dialogue)
+		if !ok {
+			log.Printf("Aviso: o diálogo '%s' de '%s' não existe.", npc.Dialogue, npc.Name)
+			return false
+		}
+		g.faceNPCToPlayer(e)
+		// Publicado antes de a conversa começar, para o diálogo já saber
+		// se alguma missão terminou com ela
+		events.Publish(g.bus, events.NPCTalked{Archetype: g.archetypeName(e), Name: npc.Name})
+		g.conversation = dialogue.Start(tree, dialogueWorld{g})
+		g.talkingTo = npc
+		return g.conversation != nil
+	}
+	return false
+}
+
+// faceNPCToPlayer vira o NPC para o jogador. Os primeiros frames do sprite
+// são ele parado olhando para baixo, cima, esquerda e direita.
+func (g *GameScene) faceNPCToPlayer(e ecs.Entity) {
+	pos, sprite := g.world.Position.Get(e), g.world.Sprite.Get(e)
+	if sprite == nil || sprite.Columns < 4 {
+		return
+	}
+	dx, dy := g.player.X-pos.X, g.player.Y-pos.Y
+	switch {
+	case abs(dx) > abs(dy) && dx < 0:
+		sprite.Frame = 2
+	case abs(dx) > abs(dy):
+		sprite.Frame = 3
+	case dy < 0:
+		sprite.Frame = 1
+	default:
+		sprite.Frame = 0
+	}
+}
+
+// Flag diz se uma flag do jogo está ligada. Flags guardam o que já
+// aconteceu (ex: "met_master") para diálogos e missões.
+func (g *GameScene) Flag(name string) bool {
+	return g.flags[name]
+}
+
+func (g *GameScene) SetFlag(name string, value bool) {
+	if value {
+		g.flags[name] = true
+	} else {
+		delete(g.flags, name)
+	}
+}
+
+// dialogueWorld deixa as conversas consultarem e mudarem o jogo.
+type dialogueWorld struct {
+	g *GameScene
+}
+
+func (w dialogueWorld) Flag(name string) bool {
+	return w.g.Flag(name)
+}
+
+func (w dialogueWorld) SetFlag(name string, value bool) {
+	w.g.SetFlag(name, value)
+}
+
+func (w dialogueWorld) ItemCount(name string) int {
+	return w.g.player.Inventory.Count(name)
+}
+
+func (w dialogueWorld) GiveItem(name string, count int) {
+	w.g.giveItem(name, count)
+}
+
+func (w dialogueWorld) TakeItem(name string, count int) {
+	w.g.player.Inventory.Remove(name, count)
+}
+
+func (w dialogueWorld) Heal(amount int) {
+	combat := w.g.player.CombatComp
+	missing := combat.MaxHealth() - combat.Health()
+	if amount <= 0 || amount > missing {
+		amount = missing
+	}
+	combat.Heal(max(amount, 0))
+	events.Publish(w.g.bus, events.PlayerHealed{Amount: max(amount, 0), Health: combat.Health()})
+}
+
+func (w dialogueWorld) StartQuest(name string) {
+	w.g.startQuest(name)
+}
+
+func (w dialogueWorld) QuestStatus(name string) string {
+	return w.g.questStatus(name)
+}
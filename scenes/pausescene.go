@@ -1,21 +1,43 @@
 package scenes
 
 import (
+	"fmt"
 	"image/color"
+	"log"
+	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"rpg-go/input"
+	"rpg-go/sound"
 )
 
 type PauseScene struct {
-	loaded bool
+	loaded  bool
+	input   *input.Handler
+	game    *GameScene
+	main    *menu
+	sound   *menu  // volumes
+	active  *menu  // menu sendo mostrado: o principal, o de slots ou o de som
+	message string // resultado da última ação (ex: "Saved to slot 1.")
 }
 
-func NewPauseScene() *PauseScene {
-	return &PauseScene{
+func NewPauseScene(in *input.Handler, game *GameScene) *PauseScene {
+	s := &PauseScene{
 		loaded: false,
+		input:  in,
+		game:   game,
 	}
+	s.main = newMenu(
+		menuItem{"Resume", func() SceneId { return GameSceneId }},
+		menuItem{"Equipment", func() SceneId { return EquipmentSceneId }},
+		menuItem{"Sound", s.openSoundMenu},
+		menuItem{"Save game", s.openSaveMenu},
+		menuItem{"Load game", s.openLoadMenu},
+		menuItem{"Exit", func() SceneId { return ExitSceneId }},
+	)
+	s.active = s.main
+	return s
 }
 
 func (s *PauseScene) Draw(screen *ebiten.Image) {
@@ -29,17 +51,104 @@ func (s *PauseScene) Draw(screen *ebiten.Image) {
 	screen.DrawImage(titileImage, &opts)
 	opts.GeoM.Reset()
 
-	image := ebiten.NewImage(200, 100)
-	opts.GeoM.Translate(100, 100)
-	ebitenutil.DebugPrint(image, "Press ENTER to unpause.")
-	screen.DrawImage(image, &opts)
-	opts.GeoM.Reset()
+	s.active.Draw(screen, 100, 100)
 
-	exitImage := ebiten.NewImage(200, 100)
-	opts.GeoM.Translate(100, 150)
-	ebitenutil.DebugPrint(exitImage, "Press ESCAPE to exit.")
-	screen.DrawImage(exitImage, &opts)
-	opts.GeoM.Reset()
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 100, 200)
+	}
+}
+
+func (s *PauseScene) openSaveMenu() SceneId {
+	s.active = s.game.slotMenu(func(slot int) SceneId {
+		if err := s.game.SaveGame(slot); err != nil {
+			log.Println(err)
+			s.message = fmt.Sprintf("Could not save to slot %d.", slot)
+		} else {
+			s.message = fmt.Sprintf("Saved to slot %d.", slot)
+		}
+		s.active = s.main
+		return PauseSceneId
+	}, s.backToMain)
+	return PauseSceneId
+}
+
+func (s *PauseScene) openLoadMenu() SceneId {
+	s.active = s.game.slotMenu(func(slot int) SceneId {
+		if err := s.game.LoadGame(slot); err != nil {
+			log.Println(err)
+			s.message = loadFailedMessage(slot, err)
+			return PauseSceneId
+		}
+		return GameSceneId
+	}, s.backToMain)
+	return PauseSceneId
+}
+
+// volumeStep é quanto cada aperto muda um volume.
+const volumeStep = 0.1
+
+// openSoundMenu mostra os volumes. Esquerda/direita mudam o volume
+// selecionado; confirmar aumenta e dá a volta no 100%.
+func (s *PauseScene) openSoundMenu() SceneId {
+	selected := 0
+	if s.sound != nil && s.active == s.sound {
+		selected = s.sound.selected
+	}
+	volumes := s.game.audio.Volumes()
+	label := func(name string, volume float64) string {
+		return fmt.Sprintf("%s: %d%%", name, int(math.Round(volume*100)))
+	}
+	cycle := func(volume float64) float64 {
+		if volume >= 1-volumeStep/2 {
+			return 0
+		}
+		return volume + volumeStep
+	}
+	s.sound = newMenu(
+		menuItem{label("Master volume", volumes.Master), func() SceneId {
+			volumes.Master = cycle(volumes.Master)
+			return s.setVolumes(volumes)
+		}},
+		menuItem{label("Music volume", volumes.Music), func() SceneId {
+			volumes.Music = cycle(volumes.Music)
+			return s.setVolumes(volumes)
+		}},
+		menuItem{label("Effects volume", volumes.SFX), func() SceneId {
+			volumes.SFX = cycle(volumes.SFX)
+			return s.setVolumes(volumes)
+		}},
+		menuItem{"Back", s.backToMain},
+	)
+	s.sound.selected = selected
+	s.active = s.sound
+	return PauseSceneId
+}
+
+// setVolumes aplica os volumes e refaz o menu com os valores novos.
+func (s *PauseScene) setVolumes(volumes sound.Volumes) SceneId {
+	s.game.audio.SetVolumes(volumes)
+	return s.openSoundMenu()
+}
+
+// adjustVolume muda em delta o volume selecionado no menu de som.
+func (s *PauseScene) adjustVolume(delta float64) {
+	volumes := s.game.audio.Volumes()
+	switch s.sound.selected {
+	case 0:
+		volumes.Master += delta
+	case 1:
+		volumes.Music += delta
+	case 2:
+		volumes.SFX += delta
+	default:
+		return
+	}
+	s.setVolumes(volumes)
+}
+
+func (s *PauseScene) backToMain() SceneId {
+	s.active = s.main
+	return PauseSceneId
 }
 
 func (s *PauseScene) FirstLoad() {
@@ -51,19 +160,36 @@ func (s *PauseScene) IsLoaded() bool {
 }
 
 func (s *PauseScene) OnEnter() {
+	s.main.selected = 0
+	s.active = s.main
+	s.message = ""
 }
 
 func (s *PauseScene) OnExit() {
 }
 
 func (s *PauseScene) Update() SceneId {
+	s.input.Update()
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		return ExitSceneId
+	// Cancel num submenu volta para o menu principal
+	if s.input.JustPressed(input.Cancel) && s.active != s.main {
+		return s.backToMain()
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+	// Pause/Cancel de novo volta direto para o jogo
+	if s.input.JustPressed(input.Pause) || s.input.JustPressed(input.Cancel) {
 		return GameSceneId
 	}
+	if s.active == s.sound {
+		if s.input.JustPressed(input.MoveLeft) {
+			s.adjustVolume(-volumeStep)
+		}
+		if s.input.JustPressed(input.MoveRight) {
+			s.adjustVolume(volumeStep)
+		}
+	}
+	if next, ok := s.active.Update(s.input); ok {
+		return next
+	}
 	return PauseSceneId
 }
 
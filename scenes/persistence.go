@@ -0,0 +1,189 @@
+package scenes
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"rpg-go/quests"
+	"rpg-go/save"
+	"slices"
+	"sort"
+	"time"
+)
+
+// markRemoved registra que um objeto do mapa atual não deve mais aparecer.
+func (g *GameScene) markRemoved(objectID int) {
+	if objectID == 0 {
+		return
+	}
+	removed, ok := g.removedObjects[g.currentMap]
+	if !ok {
+		removed = make(map[int]bool)
+		g.removedObjects[g.currentMap] = removed
+	}
+	removed[objectID] = true
+}
+
+func (g *GameScene) isRemoved(mapPath string, objectID int) bool {
+	return objectID != 0 && g.removedObjects[mapPath][objectID]
+}
+
+// SetSaveDir troca o diretório onde os slots são gravados.
+func (g *GameScene) SetSaveDir(dir string) {
+	g.saveDir = dir
+}
+
+// Snapshot captura o estado atual do jogo num formato salvável.
+func (g *GameScene) Snapshot() *save.Data {
+	data := &save.Data{
+		SavedAt: time.Now(),
+		Map:     g.currentMap,
+		Player: save.PlayerState{
+			X:         g.player.X,
+			Y:         g.player.Y,
+			Health:    g.player.CombatComp.Health(),
+			MaxHealth: g.player.CombatComp.MaxHealth(),
+			Level:     g.player.Progress.Level,
+			XP:        g.player.Progress.XP,
+		},
+		World: make(map[string]*save.MapState),
+	}
+	g.snapshotInventory(&data.Player)
+	for flag := range g.flags {
+		data.Flags = append(data.Flags, flag)
+	}
+	sort.Strings(data.Flags)
+	for _, entry := range g.questLog.Entries() {
+		data.Quests = append(data.Quests, save.QuestState{
+			Name:     entry.Quest.Name,
+			Stage:    entry.Stage,
+			Progress: slices.Clone(entry.Progress),
+		})
+	}
+	if g.checkpoint != nil {
+		data.Checkpoint = &save.Checkpoint{Map: g.checkpoint.Map, X: g.checkpoint.X, Y: g.checkpoint.Y}
+	}
+
+	for mapPath, removed := range g.removedObjects {
+		state := &save.MapState{Removed: make([]int, 0, len(removed))}
+		for id := range removed {
+			state.Removed = append(state.Removed, id)
+		}
+		sort.Ints(state.Removed)
+		data.World[mapPath] = state
+	}
+	return data
+}
+
+// ErrSavedMap é o erro de LoadGame quando o mapa do save não carrega
+// (ex: foi renomeado ou apagado).
+var ErrSavedMap = errors.New("o mapa do save não pôde ser carregado")
+
+// Restore recarrega o mapa salvo e reconstrói o estado do jogador e do
+// mundo. Se o mapa não carregar, o jogo atual continua como estava.
+func (g *GameScene) Restore(data *save.Data) error {
+	if !g.loaded {
+		g.FirstLoad()
+	}
+
+	removedObjects := make(map[string]map[int]bool)
+	for mapPath, state := range data.World {
+		removed := make(map[int]bool, len(state.Removed))
+		for _, id := range state.Removed {
+			removed[id] = true
+		}
+		removedObjects[mapPath] = removed
+	}
+
+	flags := make(map[string]bool, len(data.Flags))
+	for _, flag := range data.Flags {
+		flags[flag] = true
+	}
+
+	questLog := quests.NewLog()
+	for _, state := range data.Quests {
+		quest, ok := g.quests.Get(state.Name)
+		if !ok {
+			log.Printf("Aviso: a missão salva '%s' não existe mais.", state.Name)
+			continue
+		}
+		questLog.Restore(quest, state.Stage, state.Progress)
+	}
+
+	var checkpoint *respawnPoint
+	if data.Checkpoint != nil {
+		checkpoint = &respawnPoint{Map: data.Checkpoint.Map, X: data.Checkpoint.X, Y: data.Checkpoint.Y}
+	}
+
+	// O mapa já nasce sem o que o save diz que foi morto ou coletado
+	previous := g.removedObjects
+	g.removedObjects = removedObjects
+	if err := g.loadMap(data.Map, "default"); err != nil {
+		g.removedObjects = previous
+		return fmt.Errorf("%w: %w", ErrSavedMap, err)
+	}
+
+	g.flags = flags
+	g.questLog = questLog
+	g.hud.SetQuests(questLog)
+	g.checkpoint = checkpoint
+
+	g.player.X = data.Player.X
+	g.player.Y = data.Player.Y
+	g.entryPoint = respawnPoint{Map: data.Map, X: g.player.X, Y: g.player.Y}
+	g.player.Progress.Level = data.Player.Level
+	g.player.Progress.XP = data.Player.XP
+	g.restoreInventory(data.Player)
+	g.applyPlayerStats()
+	g.player.CombatComp.SetMaxHealth(data.Player.MaxHealth)
+	g.player.CombatComp.SetHealth(data.Player.Health)
+	g.resetPlayerState()
+	return nil
+}
+
+// SaveGame grava o jogo atual num slot.
+func (g *GameScene) SaveGame(slot int) error {
+	return save.Write(g.saveDir, slot, g.Snapshot())
+}
+
+// LoadGame carrega um slot e restaura o jogo a partir dele.
+func (g *GameScene) LoadGame(slot int) error {
+	data, err := save.Read(g.saveDir, slot)
+	if err != nil {
+		return err
+	}
+	return g.Restore(data)
+}
+
+// loadFailedMessage é o aviso dos menus quando um slot não carrega.
+func loadFailedMessage(slot int, err error) string {
+	if errors.Is(err, ErrSavedMap) {
+		return fmt.Sprintf("Slot %d: its map could not be loaded.", slot)
+	}
+	return fmt.Sprintf("Could not load slot %d.", slot)
+}
+
+// slotLabel descreve um slot para os menus, ex: "spawn.json 18/10 14:05".
+func (g *GameScene) slotLabel(slot int) string {
+	data, err := save.Read(g.saveDir, slot)
+	if errors.Is(err, save.ErrEmptySlot) {
+		return "empty"
+	}
+	if err != nil {
+		log.Println(err)
+		return "unreadable"
+	}
+	return filepath.Base(data.Map) + " " + data.SavedAt.Format("02/01 15:04")
+}
+
+// slotMenu monta um menu com um item por slot e um "Back" no final.
+func (g *GameScene) slotMenu(onSlot func(slot int) SceneId, onBack func() SceneId) *menu {
+	items := make([]menuItem, 0, save.SlotCount+1)
+	for slot := 1; slot <= save.SlotCount; slot++ {
+		label := fmt.Sprintf("Slot %d: %s", slot, g.slotLabel(slot))
+		items = append(items, menuItem{label, func() SceneId { return onSlot(slot) }})
+	}
+	items = append(items, menuItem{"Back", onBack})
+	return newMenu(items...)
+}
@@ -0,0 +1,150 @@
+package scenes
+
+import (
+	"image"
+	"log"
+	"math"
+	"rpg-go/archetypes"
+	"rpg-go/constants"
+	"rpg-go/ecs"
+	"rpg-go/events"
+	"rpg-go/input"
+)
+
+// playerProjectile é o arquétipo arremessado pelo jogador quando a arma
+// não escolhe outro.
+const playerProjectile = "shuriken"
+
+// throwProjectile cria um projétil com o centro em (x, y) andando na
+// direção (dirX, dirY). source é quem arremessou (DamageInfo.Source).
+func (g *GameScene) throwProjectile(def *archetypes.Archetype, x, y, dirX, dirY float64, team ecs.Team, source string) ecs.Entity {
+	length := math.Hypot(dirX, dirY)
+	if length == 0 {
+		return 0
+	}
+
+	width, height := def.ColliderSize()
+	e := g.spawnArchetype(def, x-float64(width)/2, y-float64(height)/2, nil, nil)
+
+	speed := def.Projectile.Speed
+	vel := g.world.Velocity.Get(e)
+	vel.Dx, vel.Dy = dirX/length*speed, dirY/length*speed
+	projectile := g.world.Projectile.Get(e)
+	projectile.Team = team
+	projectile.Damage.Source = source
+	return e
+}
+
+// handleThrow arremessa uma shuriken do jogador: para onde ele olha ou na
+// direção do cursor.
+func (g *GameScene) handleThrow() {
+	var dirX, dirY float64
+	px, py := g.player.X+constants.Tilesize/2, g.player.Y+constants.Tilesize/2
+
+	switch {
+	case g.input.JustPressed(input.PointerThrow):
+		cX, cY := g.input.CursorPosition()
+		dirX, dirY = float64(cX)-g.Camera.X-px, float64(cY)-g.Camera.Y-py
+	case g.input.JustPressed(input.Throw):
+		dirX, dirY = g.player.FacingVector()
+	default:
+		return
+	}
+	if dirX == 0 && dirY == 0 {
+		return
+	}
+
+	name := playerProjectile
+	if weapon := g.player.Equipment.Weapon(); weapon != nil && weapon.Projectile != "" {
+		name = weapon.Projectile
+	}
+	def, ok := g.archetypes.Get(name)
+	if !ok || def.Kind != archetypes.KindProjectile {
+		log.Printf("Aviso: o projétil '%s' do jogador não existe.", name)
+		return
+	}
+	if g.player.Throw() {
+		g.throwProjectile(def, px, py, dirX, dirY, ecs.TeamPlayer, playerSource)
+		events.Publish(g.bus, events.PlayerAttacked{X: px, Y: py, Ranged: true})
+	}
+}
+
+// updateRangedAttacks faz os inimigos com ataque à distância arremessarem
+// no jogador quando a IA está em posição de atacar.
+func (g *GameScene) updateRangedAttacks() {
+	w := g.world
+	px, py := g.player.X+constants.Tilesize/2, g.player.Y+constants.Tilesize/2
+
+	for _, e := range w.Query(w.Ranged, w.AI, w.Position, w.Collider) {
+		ranged := w.Ranged.Get(e)
+		ranged.Update()
+		if combat := w.Combat.Get(e); combat != nil && combat.Stunned() {
+			continue
+		}
+		if !w.AI.Get(e).WantsAttack() || !ranged.Fire() {
+			continue
+		}
+		bounds := w.Bounds(e)
+		x, y := float64(bounds.Min.X+bounds.Max.X)/2, float64(bounds.Min.Y+bounds.Max.Y)/2
+		g.throwProjectile(ranged.Projectile, x, y, px-x, py-y, ecs.TeamEnemy, g.sourceName(e))
+	}
+}
+
+// updateProjectiles move os projéteis e remove os que acabaram, bateram
+// numa parede ou acertaram alguém.
+func (g *GameScene) updateProjectiles() {
+	w := g.world
+	pRect := image.Rect(int(g.player.X), int(g.player.Y), int(g.player.X)+constants.Tilesize, int(g.player.Y)+constants.Tilesize)
+
+	for _, e := range w.Query(w.Projectile, w.Position, w.Velocity) {
+		projectile, pos, vel := w.Projectile.Get(e), w.Position.Get(e), w.Velocity.Get(e)
+
+		pos.X += vel.Dx
+		pos.Y += vel.Dy
+		projectile.LifeSpan--
+		if sprite := w.Sprite.Get(e); sprite != nil {
+			sprite.Rotation += projectile.Spin
+		}
+
+		bounds := w.Bounds(e)
+		if projectile.LifeSpan <= 0 || g.hitsWall(bounds) {
+			w.Despawn(e)
+			continue
+		}
+
+		switch projectile.Team {
+		case ecs.TeamEnemy:
+			if bounds.Overlaps(pRect) {
+				// Empurra a partir de onde o projétil estava no tick anterior
+				g.hurtPlayer(projectile.Damage, projectile.Effects, bounds.Sub(image.Pt(int(vel.Dx), int(vel.Dy))))
+				w.Despawn(e)
+			}
+
+		case ecs.TeamPlayer:
+			if target := g.targetAt(bounds); target != 0 {
+				g.hitEntity(target, projectile.Damage, projectile.Effects, vel.Dx, vel.Dy)
+				w.Despawn(e)
+			}
+		}
+	}
+}
+
+// targetAt retorna a primeira entidade golpeável que encosta em rect, ou 0.
+func (g *GameScene) targetAt(rect image.Rectangle) ecs.Entity {
+	for _, e := range g.world.Query(g.world.Position, g.world.Collider) {
+		if g.targetable(e) && g.world.Bounds(e).Overlaps(rect) {
+			return e
+		}
+	}
+	return 0
+}
+
+// rangedAttack monta o ataque à distância de um arquétipo de inimigo.
+func (g *GameScene) rangedAttack(def *archetypes.Archetype) *ecs.Ranged {
+	projectile, ok := g.archetypes.Get(def.Ranged.Projectile)
+	if !ok || projectile.Kind != archetypes.KindProjectile {
+		log.Printf("Aviso: '%s' arremessa '%s', que não é um projétil.", def.Name, def.Ranged.Projectile)
+		return nil
+	}
+	return &ecs.Ranged{Projectile: projectile, Cooldown: def.Ranged.Cooldown}
+}
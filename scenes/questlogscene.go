@@ -0,0 +1,136 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"rpg-go/input"
+	"rpg-go/quests"
+)
+
+// QuestLogScene é o diário de missões: a lista das missões começadas e,
+// embaixo, os detalhes da selecionada.
+type QuestLogScene struct {
+	loaded  bool
+	input   *input.Handler
+	game    *GameScene
+	list    *menu
+	entries []*quests.Entry // na mesma ordem do menu
+}
+
+func NewQuestLogScene(in *input.Handler, game *GameScene) *QuestLogScene {
+	return &QuestLogScene{
+		loaded: false,
+		input:  in,
+		game:   game,
+	}
+}
+
+// questLogColumns é quantas letras da fonte de debug cabem numa linha.
+const questLogColumns = 50
+
+func (s *QuestLogScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{40, 40, 60, 255})
+	ebitenutil.DebugPrintAt(screen, "QUESTS", 142, 10)
+
+	if len(s.entries) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No quests yet.", 10, 40)
+		return
+	}
+	s.list.Draw(screen, 10, 30)
+
+	y := 30 + len(s.entries)*16 + 8
+	for _, line := range questDetails(s.entries[s.list.selected]) {
+		ebitenutil.DebugPrintAt(screen, line, 10, y)
+		y += 14
+	}
+}
+
+// questDetails são as linhas com a descrição da missão, as etapas já
+// cumpridas e os objetivos da etapa atual.
+func questDetails(entry *quests.Entry) []string {
+	quest := entry.Quest
+	lines := wrapColumns(quest.Description, questLogColumns)
+	for i, stage := range quest.Stages {
+		switch {
+		case i < entry.Stage:
+			lines = append(lines, "[x] "+stage.Text)
+		case i == entry.Stage:
+			lines = append(lines, "[ ] "+stage.Text)
+			for j, objective := range stage.Objectives {
+				lines = append(lines, fmt.Sprintf("    %s %d/%d", objective.Text, entry.Progress[j], objective.Count))
+			}
+		}
+	}
+	if entry.Done() {
+		lines = append(lines, "Complete!")
+	}
+	return lines
+}
+
+// wrapColumns quebra o texto em linhas de no máximo columns letras, sem
+// cortar palavras.
+func wrapColumns(text string, columns int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > columns {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (s *QuestLogScene) FirstLoad() {
+	s.loaded = true
+}
+
+func (s *QuestLogScene) IsLoaded() bool {
+	return s.loaded
+}
+
+// OnEnter monta a lista: primeiro as missões em andamento, depois as
+// terminadas.
+func (s *QuestLogScene) OnEnter() {
+	s.entries = s.entries[:0]
+	var items []menuItem
+	for _, done := range []bool{false, true} {
+		for _, entry := range s.game.questLog.Entries() {
+			if entry.Done() != done {
+				continue
+			}
+			label := entry.Quest.Title
+			if done {
+				label += " (done)"
+			}
+			s.entries = append(s.entries, entry)
+			items = append(items, menuItem{label, func() SceneId { return QuestLogSceneId }})
+		}
+	}
+	s.list = newMenu(items...)
+}
+
+func (s *QuestLogScene) OnExit() {}
+
+func (s *QuestLogScene) Update() SceneId {
+	s.input.Update()
+
+	if s.input.JustPressed(input.QuestLog) || s.input.JustPressed(input.Cancel) || s.input.JustPressed(input.Pause) {
+		return GameSceneId
+	}
+	s.list.Update(s.input)
+	return QuestLogSceneId
+}
+
+var _ Scene = (*QuestLogScene)(nil)
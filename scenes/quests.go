@@ -0,0 +1,70 @@
+package scenes
+
+import (
+	"log"
+	"rpg-go/dialogue"
+	"rpg-go/events"
+	"rpg-go/quests"
+)
+
+// startQuest começa a missão name. Missões já começadas são ignoradas.
+func (g *GameScene) startQuest(name string) {
+	quest, ok := g.quests.Get(name)
+	if !ok {
+		log.Printf("Aviso: a missão '%s' não existe.", name)
+		return
+	}
+	if g.questLog.Start(quest) == nil {
+		return
+	}
+	events.Publish(g.bus, events.QuestStarted{Quest: quest.Name, Title: quest.Title})
+}
+
+// questEvent conta um acontecimento nas missões em andamento e dá a
+// recompensa das que terminaram.
+func (g *GameScene) questEvent(kind quests.ObjectiveType, target string) {
+	for _, entry := range g.questLog.Notify(quests.Event{Type: kind, Target: target}) {
+		if entry.Done() {
+			g.completeQuest(entry.Quest)
+			continue
+		}
+		events.Publish(g.bus, events.QuestAdvanced{Quest: entry.Quest.Name, Title: entry.Quest.Title, Stage: entry.Current().Text})
+	}
+}
+
+func (g *GameScene) completeQuest(quest *quests.Quest) {
+	events.Publish(g.bus, events.QuestCompleted{Quest: quest.Name, Title: quest.Title})
+
+	reward := quest.Reward
+	for _, flag := range reward.Flags {
+		g.SetFlag(flag, true)
+	}
+	for _, item := range reward.Items {
+		g.giveItem(item.Item, item.Count)
+	}
+	g.grantXP(reward.XP)
+}
+
+// giveItem põe count do item name na bolsa do jogador.
+func (g *GameScene) giveItem(name string, count int) {
+	item, ok := g.items.Get(name)
+	if !ok {
+		log.Printf("Aviso: o item '%s' não existe.", name)
+		return
+	}
+	added := g.player.Inventory.Add(item, count)
+	events.Publish(g.bus, events.ItemReceived{Item: item.Name, Title: item.Title, Count: added})
+}
+
+// questStatus é a situação da missão name, como os diálogos a entendem.
+func (g *GameScene) questStatus(name string) string {
+	entry := g.questLog.Get(name)
+	switch {
+	case entry == nil:
+		return dialogue.QuestNone
+	case entry.Done():
+		return dialogue.QuestDone
+	default:
+		return dialogue.QuestActive
+	}
+}
@@ -0,0 +1,120 @@
+package scenes
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"rpg-go/constants"
+)
+
+// respawnPoint é um lugar onde o jogador pode voltar depois de morrer.
+type respawnPoint struct {
+	Map  string
+	X, Y float64
+}
+
+// DeathPenalty define o que o jogador perde ao morrer e tentar de novo.
+type DeathPenalty struct {
+	// RespawnHealthPercent é quanto da vida máxima o jogador tem ao
+	// reviver (1 a 100).
+	RespawnHealthPercent int `json:"respawn_health_percent"`
+	// XPLossPercent é quanto da XP ganha desde o último nível o jogador
+	// perde (0 a 100). O nível nunca cai.
+	XPLossPercent int `json:"xp_loss_percent"`
+	// GoldLossPercent é quanto de cada moeda o jogador perde (0 a 100).
+	GoldLossPercent int `json:"gold_loss_percent"`
+}
+
+func DefaultDeathPenalty() DeathPenalty {
+	return DeathPenalty{
+		RespawnHealthPercent: 50,
+		XPLossPercent:        25,
+		GoldLossPercent:      10,
+	}
+}
+
+// LoadDeathPenalty lê a seção "death_penalty" do arquivo de gameplay.
+// Campos ausentes ficam com o valor padrão.
+func LoadDeathPenalty(path string) (DeathPenalty, error) {
+	penalty := DefaultDeathPenalty()
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return penalty, fmt.Errorf("falha ao ler o arquivo de gameplay %s: %w", path, err)
+	}
+
+	config := struct {
+		DeathPenalty *DeathPenalty `json:"death_penalty"`
+	}{&penalty}
+	if err := json.Unmarshal(contents, &config); err != nil {
+		return DefaultDeathPenalty(), fmt.Errorf("falha ao decodificar o arquivo de gameplay %s: %w", path, err)
+	}
+	return penalty, nil
+}
+
+func (g *GameScene) SetDeathPenalty(penalty DeathPenalty) {
+	g.deathPenalty = penalty
+}
+
+func (g *GameScene) PlayerDead() bool {
+	return g.player.CombatComp.Health() <= 0
+}
+
+// checkCheckpoints marca como checkpoint qualquer zona "checkpoint" que
+// o jogador estiver tocando.
+func (g *GameScene) checkCheckpoints() {
+	pRect := image.Rect(int(g.player.X), int(g.player.Y), int(g.player.X)+constants.Tilesize, int(g.player.Y)+constants.Tilesize)
+	for _, zone := range g.checkpointZones {
+		if pRect.Overlaps(zone) {
+			g.checkpoint = &respawnPoint{Map: g.currentMap, X: g.player.X, Y: g.player.Y}
+			return
+		}
+	}
+}
+
+// resetPlayerState tira do jogador o que sobrou da vida anterior ao
+// reviver ou carregar um jogo: efeitos, golpes recebidos e ações pela
+// metade.
+func (g *GameScene) resetPlayerState() {
+	g.player.CombatComp.ClearEffects()
+	g.player.CombatComp.ClearHit()
+	g.player.CancelActions()
+}
+
+// respawnPoint retorna o último checkpoint ou, se não houver, o ponto
+// onde o jogador entrou no mapa atual.
+func (g *GameScene) respawnPoint() respawnPoint {
+	if g.checkpoint != nil {
+		return *g.checkpoint
+	}
+	return g.entryPoint
+}
+
+// Respawn revive o jogador no último checkpoint aplicando a penalidade
+// de morte (vida reduzida, XP e dinheiro perdidos). O mapa é recarregado,
+// então os inimigos vivos voltam inteiros.
+func (g *GameScene) Respawn() {
+	point := g.respawnPoint()
+	g.LoadMap(point.Map, "default")
+	g.player.X = point.X
+	g.player.Y = point.Y
+	g.entryPoint = point
+
+	percent := max(1, min(g.deathPenalty.RespawnHealthPercent, 100))
+	health := max(1, g.player.CombatComp.MaxHealth()*percent/100)
+	g.player.CombatComp.SetHealth(health)
+	g.resetPlayerState()
+
+	// Perde parte da XP do nível atual, sem voltar de nível
+	progress := g.player.Progress
+	levelXP := g.leveling.Stats(progress.Level).XP
+	if earned := progress.XP - levelXP; earned > 0 {
+		progress.XP -= earned * max(0, min(g.deathPenalty.XPLossPercent, 100)) / 100
+	}
+
+	goldPercent := max(0, min(g.deathPenalty.GoldLossPercent, 100))
+	for name, amount := range g.player.Inventory.Currency() {
+		g.player.Inventory.Remove(name, amount*goldPercent/100)
+	}
+}
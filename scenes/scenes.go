@@ -9,6 +9,11 @@ const (
 	StartSceneId
 	ExitSceneId
 	PauseSceneId
+	GameOverSceneId
+	StatsSceneId
+	EquipmentSceneId
+	DialogueSceneId
+	QuestLogSceneId
 )
 
 type Scene interface {
@@ -0,0 +1,211 @@
+package scenes
+
+import (
+	"image"
+	"log"
+	"math"
+	"rpg-go/ai"
+	"rpg-go/archetypes"
+	"rpg-go/ecs"
+	"rpg-go/loot"
+	"rpg-go/pathfinding"
+	"rpg-go/tilemap"
+)
+
+// spawnObject cria a entidade de um objeto do Tiled a partir do arquétipo
+// correspondente. O objeto pode escolher o arquétipo pelo nome com a
+// propriedade "archetype" (ou "enemy_type"); senão vale o tipo do objeto.
+// Retorna false se nenhum arquétipo cria esse objeto.
+func (g *GameScene) spawnObject(obj tilemap.TiledObject, objectsByID map[int]tilemap.TiledObject) bool {
+	requested, _ := tilemap.GetStringProperty("archetype", obj.Properties)
+	if requested == "" {
+		requested, _ = tilemap.GetStringProperty("enemy_type", obj.Properties)
+	}
+
+	def, ok := g.archetypes.ForObject(obj.Type, requested)
+	if !ok {
+		return false
+	}
+	g.spawnArchetype(def, obj.X, obj.Y, &obj, objectsByID)
+	return true
+}
+
+// spawnArchetype cria uma entidade no mundo em (x, y). obj é o objeto do
+// Tiled que a originou, ou nil para entidades criadas durante o jogo
+// (ex: drops).
+func (g *GameScene) spawnArchetype(def *archetypes.Archetype, x, y float64, obj *tilemap.TiledObject, objectsByID map[int]tilemap.TiledObject) ecs.Entity {
+	img, err := g.assets.Image(def.Sprite.Image)
+	if err != nil {
+		log.Printf("Aviso: arquétipo '%s': %v", def.Name, err)
+	}
+
+	objectID := 0
+	var properties []tilemap.TiledProperty
+	if obj != nil {
+		objectID = obj.ID
+		properties = obj.Properties
+	}
+
+	e := g.world.Spawn()
+	g.world.Position.Set(e, &ecs.Position{X: x, Y: y})
+	g.world.Sprite.Set(e, &ecs.Sprite{
+		Img:         img,
+		FrameWidth:  def.Sprite.FrameWidth,
+		FrameHeight: def.Sprite.FrameHeight,
+		Columns:     def.Sprite.Columns,
+		Frame:       def.Sprite.Frame,
+	})
+	// "loot_table" no objeto troca a tabela de drops da definição
+	lootTable := def.Loot
+	if requested, ok := tilemap.GetStringProperty("loot_table", properties); ok {
+		lootTable = requested
+	}
+	g.world.Origin.Set(e, &ecs.Origin{ObjectID: objectID, Archetype: def, Loot: lootTable})
+
+	width, height := def.ColliderSize()
+	collider := &ecs.Collider{Width: float64(width), Height: float64(height)}
+
+	switch def.Kind {
+	case archetypes.KindEnemy:
+		params := def.AIParams()
+		if obj != nil {
+			params = enemyAIParams(params, *obj, objectsByID)
+		}
+		home := ai.Point{X: x + collider.Width/2, Y: y + collider.Height/2}
+		brain := ai.NewBrain(home, params)
+		brain.Nav = pathfinding.NewFollower(g.pathfinder)
+
+		g.world.Velocity.Set(e, &ecs.Velocity{})
+		g.world.Collider.Set(e, collider)
+		g.world.AI.Set(e, brain)
+		g.world.Combat.Set(e, def.Combat.NewCombat())
+		g.world.Animation.Set(e, &ecs.Animation{Set: def.NewAnimations()})
+		if def.Ranged != nil {
+			if ranged := g.rangedAttack(def); ranged != nil {
+				g.world.Ranged.Set(e, ranged)
+			}
+		}
+
+	case archetypes.KindDummy:
+		frames := 1
+		if hit, ok := def.Animations["hit"]; ok {
+			frames = hit.Last - hit.First + 1
+		}
+		flinch := ecs.NewFlinch(frames)
+		g.world.Collider.Set(e, collider)
+		g.world.Flinch.Set(e, flinch)
+		g.world.Sprite.Get(e).Frame = flinch.Frame()
+
+	case archetypes.KindPickup:
+		pickup := &ecs.Pickup{}
+		if def.Pickup != nil {
+			pickup.Heal = def.Pickup.Heal
+			pickup.Effects = archetypes.Effects(def.Pickup.Effects)
+			pickup.Item, pickup.Count = g.pickupItem(def, properties)
+		}
+		// "count" é quantos itens o objeto dá. A cura de um item vem da
+		// definição dele (use.heal), não do objeto.
+		if count, found := tilemap.GetIntProperty("count", properties); found && pickup.Item != nil {
+			pickup.Count = max(count, 1)
+		}
+		// Sem sprite próprio, o coletável mostra o ícone do item
+		if def.Sprite.Image == "" && pickup.Item != nil {
+			g.world.Sprite.Set(e, g.itemSprite(pickup.Item))
+		}
+		g.world.Collider.Set(e, collider)
+		g.world.Pickup.Set(e, pickup)
+
+	case archetypes.KindProjectile:
+		// A direção e o time são definidos por quem arremessa
+		g.world.Velocity.Set(e, &ecs.Velocity{})
+		g.world.Collider.Set(e, collider)
+		g.world.Projectile.Set(e, &ecs.Projectile{
+			Damage:   def.Projectile.DamageInfo(def.Name),
+			Effects:  archetypes.Effects(def.Projectile.OnHit),
+			LifeSpan: def.Projectile.LifeSpan,
+			Spin:     def.Projectile.Spin,
+		})
+
+	case archetypes.KindNPC:
+		// O objeto do Tiled pode trocar a conversa e o nome
+		npc := &ecs.NPC{Name: def.NPC.Name, Dialogue: def.NPC.Dialogue}
+		if dialogue, ok := tilemap.GetStringProperty("dialogue", properties); ok {
+			npc.Dialogue = dialogue
+		}
+		if name, ok := tilemap.GetStringProperty("npc_name", properties); ok {
+			npc.Name = name
+		}
+		g.world.Collider.Set(e, collider)
+		g.world.NPC.Set(e, npc)
+
+	case archetypes.KindProp:
+		// Só o sprite
+	}
+	return e
+}
+
+// itemArchetype é o arquétipo usado para itens de assets/items no chão.
+const itemArchetype = "item"
+
+// Parâmetros do pulo dos drops.
+const (
+	dropMinDistance = 6.0
+	dropMaxDistance = 16.0
+	dropPopHeight   = 10.0
+	dropPopTicks    = 24
+)
+
+// dropLoot sorteia a tabela de drops de uma entidade morta e cria os
+// drops onde ela estava.
+func (g *GameScene) dropLoot(e ecs.Entity) {
+	origin, pos := g.world.Origin.Get(e), g.world.Position.Get(e)
+	if origin == nil || origin.Loot == "" || pos == nil {
+		return
+	}
+	table, ok := g.lootTables.Get(origin.Loot)
+	if !ok {
+		log.Printf("Aviso: a tabela de drops '%s' não existe.", origin.Loot)
+		return
+	}
+	for _, drop := range table.Roll(g.rng) {
+		g.spawnDrop(drop, pos.X, pos.Y)
+	}
+}
+
+// spawnDrop cria um drop em (x, y), pulando para um lugar livre perto.
+func (g *GameScene) spawnDrop(drop loot.Drop, x, y float64) {
+	name := drop.Archetype
+	if name == "" {
+		name = itemArchetype
+	}
+	def, ok := g.archetypes.Get(name)
+	if !ok || def.Kind != archetypes.KindPickup {
+		log.Printf("Aviso: o drop '%s' não é um coletável.", name)
+		return
+	}
+
+	e := g.spawnArchetype(def, x, y, nil, nil)
+	if drop.Item != "" {
+		item, ok := g.items.Get(drop.Item)
+		if !ok {
+			log.Printf("Aviso: o item '%s' do drop não existe.", drop.Item)
+			g.world.Despawn(e)
+			return
+		}
+		pickup := g.world.Pickup.Get(e)
+		pickup.Item, pickup.Count = item, drop.Count
+		if def.Sprite.Image == "" {
+			g.world.Sprite.Set(e, g.itemSprite(item))
+		}
+	}
+
+	// Pula numa direção sorteada; se cair numa parede, fica onde nasceu
+	angle := g.rng.Float64() * 2 * math.Pi
+	distance := dropMinDistance + g.rng.Float64()*(dropMaxDistance-dropMinDistance)
+	toX, toY := x+math.Cos(angle)*distance, y+math.Sin(angle)*distance
+	width, height := def.ColliderSize()
+	if g.hitsWall(image.Rect(int(toX), int(toY), int(toX)+width, int(toY)+height)) {
+		toX, toY = x, y
+	}
+	g.world.Pop.Set(e, &ecs.Pop{FromX: x, FromY: y, ToX: toX, ToY: toY, Height: dropPopHeight, Duration: dropPopTicks})
+}
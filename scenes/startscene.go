@@ -2,20 +2,38 @@ package scenes
 
 import (
 	"image/color"
+	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"rpg-go/input"
 )
 
 type StartScene struct {
-	loaded bool
+	loaded  bool
+	input   *input.Handler
+	game    *GameScene
+	main    *menu
+	active  *menu
+	message string
 }
 
-func NewStartScene() *StartScene {
-	return &StartScene{
+func NewStartScene(in *input.Handler, game *GameScene) *StartScene {
+	s := &StartScene{
 		loaded: false,
+		input:  in,
+		game:   game,
 	}
+	s.main = newMenu(
+		menuItem{"Start", func() SceneId {
+			s.game.NewRun()
+			return GameSceneId
+		}},
+		menuItem{"Load game", s.openLoadMenu},
+		menuItem{"Exit", func() SceneId { return ExitSceneId }},
+	)
+	s.active = s.main
+	return s
 }
 
 func (s *StartScene) Draw(screen *ebiten.Image) {
@@ -24,12 +42,34 @@ func (s *StartScene) Draw(screen *ebiten.Image) {
 	image := ebiten.NewImage(200, 100)
 	opts := ebiten.DrawImageOptions{}
 
-	opts.GeoM.Translate(100, 100)
+	opts.GeoM.Translate(100, 60)
 
-	ebitenutil.DebugPrint(image, "Press ENTER to start.")
+	ebitenutil.DebugPrint(image, "RPG Go!")
 	screen.DrawImage(image, &opts)
 	opts.GeoM.Reset()
 
+	s.active.Draw(screen, 100, 100)
+
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 100, 200)
+	}
+}
+
+func (s *StartScene) openLoadMenu() SceneId {
+	s.active = s.game.slotMenu(func(slot int) SceneId {
+		if err := s.game.LoadGame(slot); err != nil {
+			log.Println(err)
+			s.message = loadFailedMessage(slot, err)
+			return StartSceneId
+		}
+		return GameSceneId
+	}, s.backToMain)
+	return StartSceneId
+}
+
+func (s *StartScene) backToMain() SceneId {
+	s.active = s.main
+	return StartSceneId
 }
 
 func (s *StartScene) FirstLoad() {
@@ -41,15 +81,22 @@ func (s *StartScene) IsLoaded() bool {
 }
 
 func (s *StartScene) OnEnter() {
+	s.main.selected = 0
+	s.active = s.main
+	s.message = ""
 }
 
 func (s *StartScene) OnExit() {
 }
 
 func (s *StartScene) Update() SceneId {
+	s.input.Update()
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		return GameSceneId
+	if s.input.JustPressed(input.Cancel) && s.active != s.main {
+		return s.backToMain()
+	}
+	if next, ok := s.active.Update(s.input); ok {
+		return next
 	}
 	return StartSceneId
 }
@@ -0,0 +1,100 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"rpg-go/components"
+	"rpg-go/input"
+)
+
+// StatsScene mostra o nível, a experiência e os atributos do jogador.
+type StatsScene struct {
+	loaded bool
+	input  *input.Handler
+	game   *GameScene
+}
+
+func NewStatsScene(in *input.Handler, game *GameScene) *StatsScene {
+	return &StatsScene{
+		loaded: false,
+		input:  in,
+		game:   game,
+	}
+}
+
+func (s *StatsScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{40, 40, 60, 255})
+	ebitenutil.DebugPrintAt(screen, "STATUS", 136, 10)
+
+	y := 40
+	for _, line := range s.game.statLines() {
+		ebitenutil.DebugPrintAt(screen, line, 40, y)
+		y += 16
+	}
+}
+
+// statLines são as linhas de texto da tela de atributos.
+func (g *GameScene) statLines() []string {
+	progress := g.player.Progress
+	combat := g.player.CombatComp
+
+	xp := fmt.Sprintf("XP: %d", progress.XP)
+	if next, ok := g.leveling.NextLevelXP(progress.Level); ok {
+		xp = fmt.Sprintf("XP: %d / %d", progress.XP, next)
+	}
+
+	attack := combat.AttackDamage()
+	lines := []string{
+		fmt.Sprintf("Level: %d", progress.Level),
+		xp,
+		fmt.Sprintf("Health: %d / %d", combat.Health(), combat.MaxHealth()),
+		fmt.Sprintf("Attack: %d (%s)", attack.Amount, attack.Type),
+		fmt.Sprintf("Critical: %.0f%% x%.1f", attack.CritChance*100, critMultiplier(attack)),
+		fmt.Sprintf("Armor: %d", combat.Defense().Armor),
+		fmt.Sprintf("Attack speed: %d ticks", g.player.AttackDuration()),
+	}
+
+	// Resistências em ordem alfabética para a tela não mudar a cada frame
+	resistances := combat.Defense().Resistances
+	types := make([]string, 0, len(resistances))
+	for damageType := range resistances {
+		types = append(types, string(damageType))
+	}
+	sort.Strings(types)
+	for _, damageType := range types {
+		lines = append(lines, fmt.Sprintf("%s resistance: %.0f%%", damageType, resistances[components.DamageType(damageType)]*100))
+	}
+	return lines
+}
+
+func critMultiplier(info components.DamageInfo) float64 {
+	if info.CritMultiplier == 0 {
+		return components.DefaultCritMultiplier
+	}
+	return info.CritMultiplier
+}
+
+func (s *StatsScene) FirstLoad() {
+	s.loaded = true
+}
+
+func (s *StatsScene) IsLoaded() bool {
+	return s.loaded
+}
+
+func (s *StatsScene) OnEnter() {}
+
+func (s *StatsScene) OnExit() {}
+
+func (s *StatsScene) Update() SceneId {
+	s.input.Update()
+
+	if s.input.JustPressed(input.Stats) || s.input.JustPressed(input.Cancel) || s.input.JustPressed(input.Pause) {
+		return GameSceneId
+	}
+	return StatsSceneId
+}
@@ -0,0 +1,162 @@
+package scenes
+
+import (
+	"image"
+	"rpg-go/ai"
+	"rpg-go/constants"
+	"rpg-go/ecs"
+)
+
+// Sistemas que percorrem as entidades do mundo a cada tick.
+
+// updateAI decide para onde cada inimigo quer andar.
+func (g *GameScene) updateAI() {
+	w := g.world
+	playerCenter := ai.Point{X: g.player.X + constants.Tilesize/2, Y: g.player.Y + constants.Tilesize/2}
+
+	for _, e := range w.Query(w.AI, w.Position, w.Velocity) {
+		bounds := w.Bounds(e)
+		self := ai.Point{
+			X: float64(bounds.Min.X+bounds.Max.X) / 2,
+			Y: float64(bounds.Min.Y+bounds.Max.Y) / 2,
+		}
+
+		perception := ai.Perception{
+			Self:   self,
+			Target: playerCenter,
+			TargetVisible: g.CollisionGrid.LineOfSight(
+				image.Pt(int(self.X), int(self.Y)),
+				image.Pt(int(playerCenter.X), int(playerCenter.Y)),
+			),
+		}
+		vel := w.Velocity.Get(e)
+		if combat := w.Combat.Get(e); combat != nil {
+			// Atordoado não pensa nem anda (o empurrão é aplicado em moveEntities)
+			if combat.Stunned() {
+				vel.Dx, vel.Dy = 0, 0
+				continue
+			}
+			perception.Health = combat.Health()
+			perception.MaxHealth = combat.MaxHealth()
+		}
+
+		vel.Dx, vel.Dy = w.AI.Get(e).Update(perception)
+	}
+}
+
+// updateCombat conta o tempo de recarga dos ataques.
+func (g *GameScene) updateCombat() {
+	for _, e := range g.world.Query(g.world.Combat) {
+		g.world.Combat.Get(e).Update()
+	}
+}
+
+// removeDead tira do mundo as entidades mortas pelos efeitos de status
+// (veneno, queimadura...) no updateCombat.
+func (g *GameScene) removeDead() {
+	for _, e := range g.world.Query(g.world.Combat) {
+		g.killIfDead(e)
+	}
+}
+
+// moveEntities aplica a velocidade, parando nos colisores do mapa.
+// Projéteis têm o próprio sistema.
+func (g *GameScene) moveEntities() {
+	w := g.world
+	for _, e := range w.Query(w.Position, w.Velocity, w.Collider) {
+		if w.Projectile.Has(e) {
+			continue
+		}
+		pos, vel, col := w.Position.Get(e), w.Velocity.Get(e), w.Collider.Get(e)
+
+		// Quem foi empurrado por um golpe vai para onde o empurrão manda
+		if combat := w.Combat.Get(e); combat != nil {
+			if kx, ky := combat.Knockback(); kx != 0 || ky != 0 {
+				vel.Dx, vel.Dy = kx, ky
+			} else {
+				// Efeitos como lentidão
+				vel.Dx *= combat.SpeedMultiplier()
+				vel.Dy *= combat.SpeedMultiplier()
+			}
+		}
+
+		pos.X += vel.Dx
+		pos.X = collideHorizontally(pos.X, pos.Y, vel.Dx, col.Width, col.Height, g.CollisionGrid)
+		pos.Y += vel.Dy
+		pos.Y = collideVertically(pos.X, pos.Y, vel.Dy, col.Width, col.Height, g.CollisionGrid)
+	}
+}
+
+// animateEntities escolhe a animação pela direção do movimento e atualiza
+// o frame do sprite.
+func (g *GameScene) animateEntities() {
+	w := g.world
+	for _, e := range w.Query(w.Animation, w.Sprite) {
+		anim, sprite := w.Animation.Get(e), w.Sprite.Get(e)
+
+		anim.Current = "idle"
+		if vel := w.Velocity.Get(e); vel != nil {
+			anim.Current = directionName(vel.Dx, vel.Dy)
+		}
+
+		current, ok := anim.Set[anim.Current]
+		if !ok {
+			sprite.Frame = 0
+			continue
+		}
+		current.Update()
+		sprite.Frame = current.Frame()
+	}
+
+	for _, e := range w.Query(w.Flinch, w.Sprite) {
+		flinch := w.Flinch.Get(e)
+		flinch.Update()
+		w.Sprite.Get(e).Frame = flinch.Frame()
+	}
+}
+
+// directionName é o nome da animação para andar em (dx, dy).
+func directionName(dx, dy float64) string {
+	switch {
+	case dx > 0 && dx >= abs(dy):
+		return "right"
+	case dx < 0 && -dx >= abs(dy):
+		return "left"
+	case dy > 0:
+		return "down"
+	case dy < 0:
+		return "up"
+	}
+	return "idle"
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// updatePops anda com os drops que ainda estão pulando.
+func (g *GameScene) updatePops() {
+	w := g.world
+	for _, e := range w.Query(w.Pop, w.Position, w.Sprite) {
+		pos, sprite := w.Position.Get(e), w.Sprite.Get(e)
+		x, y, height, done := w.Pop.Get(e).Update()
+		pos.X, pos.Y = x, y
+		sprite.OffsetY = -height
+		if done {
+			w.Pop.Remove(e)
+		}
+	}
+}
+
+// hitsWall diz se o retângulo encosta em algum colisor do mapa.
+func (g *GameScene) hitsWall(rect image.Rectangle) bool {
+	return g.CollisionGrid.Overlaps(rect)
+}
+
+// targetable diz se a entidade pode ser golpeada pelo jogador.
+func (g *GameScene) targetable(e ecs.Entity) bool {
+	return g.world.Combat.Has(e) || g.world.Flinch.Has(e)
+}
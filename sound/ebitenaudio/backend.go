@@ -0,0 +1,88 @@
+// Package ebitenaudio toca os sons do pacote sound pela placa de som.
+// Fica separado para que quem só usa o sound (as cenas, os testes) não
+// dependa do driver de áudio do sistema.
+package ebitenaudio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"rpg-go/sound"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+// SampleRate é a taxa do contexto de áudio; os arquivos são convertidos
+// para ela ao decodificar.
+const SampleRate = 44100
+
+// Backend toca os sons pela placa de som, com o pacote audio do Ebiten.
+// Cada arquivo é decodificado uma vez só e fica na memória.
+type Backend struct {
+	context *audio.Context
+	decoded map[string][]byte // PCM já decodificado, por arquivo
+}
+
+// NewBackend cria o contexto de áudio do Ebiten. Só pode existir um
+// por processo.
+func NewBackend() *Backend {
+	return &Backend{
+		context: audio.NewContext(SampleRate),
+		decoded: make(map[string][]byte),
+	}
+}
+
+func (b *Backend) NewPlayer(path string, loop bool) (sound.Player, error) {
+	pcm, err := b.decode(path)
+	if err != nil {
+		return nil, err
+	}
+	if !loop {
+		return b.context.NewPlayerFromBytes(pcm), nil
+	}
+	stream := audio.NewInfiniteLoop(bytes.NewReader(pcm), int64(len(pcm)))
+	player, err := b.context.NewPlayer(stream)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao tocar %s: %w", path, err)
+	}
+	return player, nil
+}
+
+func (b *Backend) decode(path string) ([]byte, error) {
+	if pcm, ok := b.decoded[path]; ok {
+		return pcm, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir o som: %w", err)
+	}
+	defer f.Close()
+
+	var stream io.Reader
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ogg":
+		stream, err = vorbis.DecodeWithSampleRate(SampleRate, f)
+	case ".wav":
+		stream, err = wav.DecodeWithSampleRate(SampleRate, f)
+	case ".mp3":
+		stream, err = mp3.DecodeWithSampleRate(SampleRate, f)
+	default:
+		return nil, fmt.Errorf("formato de som não suportado: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao decodificar o som: %w", err)
+	}
+	pcm, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao decodificar o som: %w", err)
+	}
+	b.decoded[path] = pcm
+	return pcm, nil
+}
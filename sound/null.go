@@ -0,0 +1,42 @@
+package sound
+
+// NullBackend não toca nada, mas lembra o que pediram para tocar, para os
+// testes conferirem.
+type NullBackend struct {
+	Played []string // arquivos, na ordem em que começaram a tocar
+}
+
+func (b *NullBackend) NewPlayer(path string, loop bool) (Player, error) {
+	return &nullPlayer{backend: b, path: path, loop: loop}, nil
+}
+
+// nullPlayer termina na hora, a não ser que esteja em loop.
+type nullPlayer struct {
+	backend *NullBackend
+	path    string
+	loop    bool
+	playing bool
+	volume  float64
+}
+
+func (p *nullPlayer) Play() {
+	p.backend.Played = append(p.backend.Played, p.path)
+	p.playing = p.loop
+}
+
+func (p *nullPlayer) Pause() {
+	p.playing = false
+}
+
+func (p *nullPlayer) IsPlaying() bool {
+	return p.playing
+}
+
+func (p *nullPlayer) SetVolume(volume float64) {
+	p.volume = volume
+}
+
+func (p *nullPlayer) Close() error {
+	p.playing = false
+	return nil
+}
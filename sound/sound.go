@@ -0,0 +1,262 @@
+// Package sound cuida da música de fundo e dos efeitos sonoros: troca a
+// música com crossfade e abaixa o volume dos efeitos longe da câmera.
+package sound
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Backend toca os sons de verdade. ebitenaudio.NewBackend usa a placa de som;
+// NullBackend não toca nada (testes e o modo headless).
+type Backend interface {
+	// NewPlayer prepara o som do arquivo path (.ogg, .wav ou .mp3). Com
+	// loop o som recomeça sozinho quando acaba.
+	NewPlayer(path string, loop bool) (Player, error)
+}
+
+// Player é um som pronto para tocar.
+type Player interface {
+	Play()
+	Pause()
+	IsPlaying() bool
+	SetVolume(volume float64) // 0 a 1
+	Close() error
+}
+
+// Volumes são os volumes de 0 a 1. Master multiplica os outros dois.
+type Volumes struct {
+	Master float64 `json:"master"`
+	Music  float64 `json:"music"`
+	SFX    float64 `json:"sfx"`
+}
+
+// Falloff é como o volume de um efeito cai com a distância até a câmera:
+// cheio até MinDistance e mudo a partir de MaxDistance (em pixels).
+type Falloff struct {
+	MinDistance float64 `json:"min_distance"`
+	MaxDistance float64 `json:"max_distance"`
+}
+
+// Config é a configuração de som, lida de assets/config/audio.json.
+type Config struct {
+	Volumes Volumes `json:"volumes"`
+	// CrossfadeTicks é quanto dura a troca de uma música para outra
+	CrossfadeTicks int     `json:"crossfade_ticks"`
+	Falloff        Falloff `json:"falloff"`
+	// Sounds associa o nome de cada efeito (ex: "hit") ao arquivo, relativo
+	// ao arquivo de configuração
+	Sounds map[string]string `json:"sounds"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Volumes:        Volumes{Master: 0.8, Music: 0.6, SFX: 1},
+		CrossfadeTicks: 60,
+		Falloff:        Falloff{MinDistance: 48, MaxDistance: 240},
+		Sounds:         map[string]string{},
+	}
+}
+
+// LoadConfig lê a configuração de som. Campos que faltam ficam com os
+// valores de DefaultConfig.
+func LoadConfig(path string) (Config, error) {
+	config := DefaultConfig()
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("falha ao ler a configuração de som %s: %w", path, err)
+	}
+	if err := json.Unmarshal(contents, &config); err != nil {
+		return DefaultConfig(), fmt.Errorf("falha ao decodificar a configuração de som %s: %w", path, err)
+	}
+	if config.Falloff.MaxDistance <= config.Falloff.MinDistance {
+		return DefaultConfig(), fmt.Errorf("%s: max_distance precisa ser maior que min_distance", path)
+	}
+	for name, file := range config.Sounds {
+		config.Sounds[name] = filepath.Join(filepath.Dir(path), file)
+	}
+	return config, nil
+}
+
+// Volume do efeito a uma distância da câmera, de 0 a 1.
+func (f Falloff) Volume(distance float64) float64 {
+	switch {
+	case distance <= f.MinDistance:
+		return 1
+	case distance >= f.MaxDistance:
+		return 0
+	}
+	return 1 - (distance-f.MinDistance)/(f.MaxDistance-f.MinDistance)
+}
+
+// track é uma música tocando.
+type track struct {
+	path   string
+	player Player
+	fade   float64 // 0 a 1, multiplicado pelo volume da música
+}
+
+// Manager toca a música e os efeitos. Update precisa ser chamado a cada
+// tick para o crossfade andar.
+type Manager struct {
+	backend Backend
+	config  Config
+
+	music  *track   // a música atual
+	fading []*track // músicas antigas sumindo
+	sfx    []Player // efeitos ainda tocando
+
+	listenerX, listenerY float64 // de onde se ouve (o meio da câmera)
+
+	failed map[string]bool // arquivos que não abriram, para avisar uma vez só
+}
+
+func NewManager(backend Backend, config Config) *Manager {
+	return &Manager{
+		backend: backend,
+		config:  config,
+		failed:  make(map[string]bool),
+	}
+}
+
+// NewNullManager é um Manager que não toca nada.
+func NewNullManager() *Manager {
+	return NewManager(&NullBackend{}, DefaultConfig())
+}
+
+func (m *Manager) newPlayer(path string, loop bool) Player {
+	if m.failed[path] {
+		return nil
+	}
+	player, err := m.backend.NewPlayer(path, loop)
+	if err != nil {
+		log.Printf("Aviso: som %s: %v", path, err)
+		m.failed[path] = true
+		return nil
+	}
+	return player
+}
+
+// PlayMusic troca a música de fundo, com crossfade. A mesma música
+// continua de onde está; "" só faz a música atual sumir.
+func (m *Manager) PlayMusic(path string) {
+	if m.music != nil && m.music.path == path {
+		return
+	}
+	if m.music != nil {
+		m.fading = append(m.fading, m.music)
+		m.music = nil
+	}
+	if path == "" {
+		return
+	}
+	player := m.newPlayer(path, true)
+	if player == nil {
+		return
+	}
+	m.music = &track{path: path, player: player}
+	if m.config.CrossfadeTicks <= 0 {
+		m.music.fade = 1
+	}
+	m.applyMusicVolume(m.music)
+	player.Play()
+}
+
+// MusicPath é o arquivo da música atual ("" se não tem).
+func (m *Manager) MusicPath() string {
+	if m.music == nil {
+		return ""
+	}
+	return m.music.path
+}
+
+// SetListener muda de onde os efeitos são ouvidos.
+func (m *Manager) SetListener(x, y float64) {
+	m.listenerX, m.listenerY = x, y
+}
+
+// PlaySound toca o efeito name (de Config.Sounds) que aconteceu em (x, y),
+// mais baixo quanto mais longe estiver de quem ouve.
+func (m *Manager) PlaySound(name string, x, y float64) {
+	falloff := m.config.Falloff.Volume(math.Hypot(x-m.listenerX, y-m.listenerY))
+	volume := m.config.Volumes.Master * m.config.Volumes.SFX * falloff
+	if volume <= 0 {
+		return
+	}
+	path, ok := m.config.Sounds[name]
+	if !ok {
+		return
+	}
+	player := m.newPlayer(path, false)
+	if player == nil {
+		return
+	}
+	player.SetVolume(volume)
+	player.Play()
+	if !player.IsPlaying() {
+		player.Close()
+		return
+	}
+	m.sfx = append(m.sfx, player)
+}
+
+// Update anda com o crossfade e libera os efeitos que já acabaram.
+func (m *Manager) Update() {
+	step := 1.0
+	if m.config.CrossfadeTicks > 0 {
+		step = 1 / float64(m.config.CrossfadeTicks)
+	}
+
+	if m.music != nil && m.music.fade < 1 {
+		m.music.fade = min(m.music.fade+step, 1)
+		m.applyMusicVolume(m.music)
+	}
+	fading := m.fading[:0]
+	for _, old := range m.fading {
+		old.fade -= step
+		if old.fade <= 0 {
+			old.player.Close()
+			continue
+		}
+		m.applyMusicVolume(old)
+		fading = append(fading, old)
+	}
+	clear(m.fading[len(fading):])
+	m.fading = fading
+
+	playing := m.sfx[:0]
+	for _, player := range m.sfx {
+		if player.IsPlaying() {
+			playing = append(playing, player)
+		} else {
+			player.Close()
+		}
+	}
+	clear(m.sfx[len(playing):])
+	m.sfx = playing
+}
+
+func (m *Manager) applyMusicVolume(t *track) {
+	t.player.SetVolume(m.config.Volumes.Master * m.config.Volumes.Music * t.fade)
+}
+
+func (m *Manager) Volumes() Volumes {
+	return m.config.Volumes
+}
+
+// SetVolumes troca os volumes (limitados entre 0 e 1). A música muda na
+// hora; os efeitos, a partir do próximo.
+func (m *Manager) SetVolumes(volumes Volumes) {
+	clamp := func(v float64) float64 { return min(max(v, 0), 1) }
+	m.config.Volumes = Volumes{Master: clamp(volumes.Master), Music: clamp(volumes.Music), SFX: clamp(volumes.SFX)}
+	if m.music != nil {
+		m.applyMusicVolume(m.music)
+	}
+	for _, old := range m.fading {
+		m.applyMusicVolume(old)
+	}
+}
@@ -0,0 +1,54 @@
+package sound
+
+import "testing"
+
+func TestPlayMusicCrossfade(t *testing.T) {
+	config := DefaultConfig()
+	config.Volumes = Volumes{Master: 1, Music: 1, SFX: 1}
+	config.CrossfadeTicks = 4
+	m := NewManager(&NullBackend{}, config)
+
+	m.PlayMusic("a.ogg")
+	for range config.CrossfadeTicks {
+		m.Update()
+	}
+	old := m.music.player.(*nullPlayer)
+	if old.volume != 1 {
+		t.Fatalf("volume da primeira música = %v, esperava 1", old.volume)
+	}
+
+	m.PlayMusic("b.ogg")
+	next := m.music.player.(*nullPlayer)
+	m.Update()
+	if old.volume != 0.75 || next.volume != 0.25 || !old.IsPlaying() {
+		t.Fatalf("no meio do crossfade: antiga %v, nova %v", old.volume, next.volume)
+	}
+	for range config.CrossfadeTicks - 1 {
+		m.Update()
+	}
+	if old.IsPlaying() || len(m.fading) > 0 {
+		t.Fatal("a música antiga não foi fechada depois do crossfade")
+	}
+	if next.volume != 1 || m.MusicPath() != "b.ogg" {
+		t.Fatalf("música nova %q com volume %v", m.MusicPath(), next.volume)
+	}
+}
+
+func TestFalloffVolume(t *testing.T) {
+	f := Falloff{MinDistance: 50, MaxDistance: 150}
+	for _, c := range []struct{ distance, want float64 }{
+		{0, 1}, {50, 1}, {100, 0.5}, {150, 0}, {1000, 0},
+	} {
+		if got := f.Volume(c.distance); got != c.want {
+			t.Errorf("Volume(%v) = %v, esperava %v", c.distance, got, c.want)
+		}
+	}
+}
+
+func TestSetVolumesClamps(t *testing.T) {
+	m := NewNullManager()
+	m.SetVolumes(Volumes{Master: -1, Music: 2, SFX: 0.5})
+	if want := (Volumes{Master: 0, Music: 1, SFX: 0.5}); m.Volumes() != want {
+		t.Fatalf("volumes = %+v, esperava %+v", m.Volumes(), want)
+	}
+}
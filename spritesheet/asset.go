@@ -5,26 +5,39 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
+// Assets carrega cada imagem uma vez só e reaproveita nas próximas vezes.
 type Assets struct {
-	SkeletonImg *ebiten.Image
-	PotionImg   *ebiten.Image
-	DummyImg    *ebiten.Image
+	images   map[string]*ebiten.Image
+	headless bool
 }
 
-func LoadAssets() (*Assets, error) {
-	skeletonImg, _, err := ebitenutil.NewImageFromFile("./assets/images/skeleton.png")
-	if err != nil {
-		return nil, err
+func NewAssets() *Assets {
+	return &Assets{
+		images: make(map[string]*ebiten.Image),
 	}
-	dummyImg, _, err := ebitenutil.NewImageFromFile("./assets/images/dummy.png")
-	if err != nil {
-		return nil, err
+}
+
+// NewHeadlessAssets cria um Assets que nunca lê imagens: Image sempre
+// retorna nil. Usado quando o jogo roda sem janela.
+func NewHeadlessAssets() *Assets {
+	return &Assets{
+		images:   make(map[string]*ebiten.Image),
+		headless: true,
 	}
+}
 
-	potionImg, _, err := ebitenutil.NewImageFromFile("./assets/images/health.png")
+func (a *Assets) Image(path string) (*ebiten.Image, error) {
+	if a.headless || path == "" {
+		return nil, nil
+	}
+	if img, ok := a.images[path]; ok {
+		return img, nil
+	}
+
+	img, _, err := ebitenutil.NewImageFromFile(path)
 	if err != nil {
 		return nil, err
 	}
-
-	return &Assets{skeletonImg, potionImg, dummyImg}, nil
+	a.images[path] = img
+	return img, nil
 }
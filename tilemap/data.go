@@ -0,0 +1,175 @@
+package tilemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Dados das camadas de tiles. O Tiled salva os GIDs como lista (CSV) ou
+// em base64, comprimidos ou não com zlib, gzip ou zstd. Mapas infinitos
+// dividem cada camada em pedaços (chunks), que aqui viram uma camada só.
+
+// chunk é um pedaço de uma camada de mapa infinito, em tiles.
+type chunk struct {
+	X, Y, Width, Height int
+	Data                []int
+}
+
+type jsonChunk struct {
+	X      int             `json:"x"`
+	Y      int             `json:"y"`
+	Width  int             `json:"width"`
+	Height int             `json:"height"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// UnmarshalJSON lê a camada decodificando "data" e "chunks" conforme
+// "encoding" e "compression".
+func (l *TilemapLayerJSON) UnmarshalJSON(contents []byte) error {
+	type plain TilemapLayerJSON
+	var raw struct {
+		plain
+		Data        json.RawMessage `json:"data"`
+		Chunks      []jsonChunk     `json:"chunks"`
+		Encoding    string          `json:"encoding"`
+		Compression string          `json:"compression"`
+	}
+	if err := json.Unmarshal(contents, &raw); err != nil {
+		return err
+	}
+	*l = TilemapLayerJSON(raw.plain)
+
+	if len(raw.Chunks) > 0 {
+		chunks := make([]chunk, len(raw.Chunks))
+		for i, c := range raw.Chunks {
+			data, err := decodeJSONData(c.Data, raw.Encoding, raw.Compression)
+			if err != nil {
+				return fmt.Errorf("camada %q: pedaço (%d, %d): %w", l.Name, c.X, c.Y, err)
+			}
+			chunks[i] = chunk{X: c.X, Y: c.Y, Width: c.Width, Height: c.Height, Data: data}
+		}
+		return l.mergeChunks(chunks)
+	}
+
+	data, err := decodeJSONData(raw.Data, raw.Encoding, raw.Compression)
+	if err != nil {
+		return fmt.Errorf("camada %q: %w", l.Name, err)
+	}
+	l.Data = data
+	return nil
+}
+
+// decodeJSONData lê o "data" de uma camada em JSON: uma lista de GIDs ou
+// uma string em base64.
+func decodeJSONData(raw json.RawMessage, encoding, compression string) ([]int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	if encoding != "base64" {
+		var data []int
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, err
+	}
+	return decodeBase64(text, compression)
+}
+
+// decodeCSV lê GIDs separados por vírgula (o formato CSV do .tmx).
+func decodeCSV(text string) ([]int, error) {
+	var data []int
+	for _, field := range strings.Split(text, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		gid, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("GID inválido %q", field)
+		}
+		data = append(data, int(gid))
+	}
+	return data, nil
+}
+
+// decodeBase64 lê GIDs em base64: inteiros de 32 bits little-endian,
+// talvez comprimidos.
+func decodeBase64(text, compression string) ([]int, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("base64 inválido: %w", err)
+	}
+
+	switch compression {
+	case "":
+	case "zlib", "gzip":
+		var r io.ReadCloser
+		if compression == "zlib" {
+			r, err = zlib.NewReader(bytes.NewReader(raw))
+		} else {
+			r, err = gzip.NewReader(bytes.NewReader(raw))
+		}
+		if err == nil {
+			raw, err = io.ReadAll(r)
+			r.Close()
+		}
+	case "zstd":
+		var d *zstd.Decoder
+		if d, err = zstd.NewReader(nil); err == nil {
+			raw, err = d.DecodeAll(raw, nil)
+			d.Close()
+		}
+	default:
+		return nil, fmt.Errorf("compressão %q não suportada", compression)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao descomprimir (%s): %w", compression, err)
+	}
+
+	if len(raw)%4 != 0 {
+		return nil, fmt.Errorf("dados com %d bytes, que não é múltiplo de 4", len(raw))
+	}
+	data := make([]int, len(raw)/4)
+	for i := range data {
+		data[i] = int(binary.LittleEndian.Uint32(raw[i*4:]))
+	}
+	return data, nil
+}
+
+// mergeChunks junta os pedaços numa camada do tamanho do retângulo que
+// cobre todos eles. Células sem pedaço ficam vazias (GID 0).
+func (l *TilemapLayerJSON) mergeChunks(chunks []chunk) error {
+	var bounds image.Rectangle
+	for _, c := range chunks {
+		if len(c.Data) != c.Width*c.Height {
+			return fmt.Errorf("camada %q: pedaço (%d, %d) com %d tiles em vez de %dx%d", l.Name, c.X, c.Y, len(c.Data), c.Width, c.Height)
+		}
+		bounds = bounds.Union(image.Rect(c.X, c.Y, c.X+c.Width, c.Y+c.Height))
+	}
+
+	l.StartX, l.StartY = bounds.Min.X, bounds.Min.Y
+	l.Width, l.Height = bounds.Dx(), bounds.Dy()
+	l.Data = make([]int, l.Width*l.Height)
+	for _, c := range chunks {
+		for row := 0; row < c.Height; row++ {
+			start := (c.Y-l.StartY+row)*l.Width + c.X - l.StartX
+			copy(l.Data[start:start+c.Width], c.Data[row*c.Width:(row+1)*c.Width])
+		}
+	}
+	return nil
+}
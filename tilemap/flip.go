@@ -0,0 +1,89 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Bits que o Tiled liga no GID de um tile espelhado ou girado. O resto do
+// número é o GID de verdade.
+const (
+	FlipHorizontal = 0x80000000
+	FlipVertical   = 0x40000000
+	FlipDiagonal   = 0x20000000 // troca x e y (junto com os outros, gira 90°)
+	RotateHex120   = 0x10000000 // só em mapas hexagonais: gira 120°
+
+	flipMask = FlipHorizontal | FlipVertical | FlipDiagonal | RotateHex120
+)
+
+// Tile é um GID de camada separado dos bits de espelhamento.
+type Tile struct {
+	ID    int // GID sem os bits (0 = vazio)
+	Flags int
+}
+
+// DecodeGID separa o GID dos bits de espelhamento.
+func DecodeGID(gid int) Tile {
+	return Tile{ID: gid &^ flipMask, Flags: gid & flipMask}
+}
+
+// Encode junta de novo o GID e os bits.
+func (t Tile) Encode() int {
+	return t.ID | t.Flags
+}
+
+// GeoM é a transformação que desenha a imagem do tile (w x h) espelhada
+// e girada, com o resultado começando em (0, 0). Na ordem do Tiled: giro
+// hexagonal, troca de x e y e depois os espelhamentos.
+func (t Tile) GeoM(w, h float64) ebiten.GeoM {
+	var g ebiten.GeoM
+	if t.Flags == 0 {
+		return g
+	}
+	if t.Flags&RotateHex120 != 0 {
+		g.Translate(-w/2, -h/2)
+		g.Rotate(2 * math.Pi / 3)
+		g.Translate(w/2, h/2)
+	}
+	if t.Flags&FlipDiagonal != 0 {
+		var swap ebiten.GeoM
+		swap.SetElement(0, 0, 0)
+		swap.SetElement(0, 1, 1)
+		swap.SetElement(1, 0, 1)
+		swap.SetElement(1, 1, 0)
+		g.Concat(swap)
+		w, h = h, w
+	}
+	if t.Flags&FlipHorizontal != 0 {
+		g.Scale(-1, 1)
+		g.Translate(w, 0)
+	}
+	if t.Flags&FlipVertical != 0 {
+		g.Scale(1, -1)
+		g.Translate(0, h)
+	}
+	return g
+}
+
+// TransformRect leva um retângulo dentro da imagem do tile (w x h) para
+// onde ele fica com o tile espelhado, como em GeoM.
+func (t Tile) TransformRect(r image.Rectangle, w, h int) image.Rectangle {
+	if t.Flags == 0 {
+		return r
+	}
+	g := t.GeoM(float64(w), float64(h))
+	var result image.Rectangle
+	for i, corner := range []image.Point{r.Min, {r.Max.X, r.Min.Y}, {r.Min.X, r.Max.Y}, r.Max} {
+		x, y := g.Apply(float64(corner.X), float64(corner.Y))
+		p := image.Pt(int(math.Round(x)), int(math.Round(y)))
+		if i == 0 {
+			result = image.Rectangle{Min: p, Max: p}
+			continue
+		}
+		result.Min.X, result.Min.Y = min(result.Min.X, p.X), min(result.Min.Y, p.Y)
+		result.Max.X, result.Max.Y = max(result.Max.X, p.X), max(result.Max.Y, p.Y)
+	}
+	return result
+}
@@ -0,0 +1,165 @@
+package tilemap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// objectTemplate é um template do Tiled: um objeto modelo que os objetos
+// do mapa usam como base.
+type objectTemplate struct {
+	Object TiledObject
+	// Tileset do GID de Object (só em templates de tile), com o caminho
+	// já relativo ao projeto
+	TilesetSource   string
+	TilesetFirstGID int
+}
+
+// Formato XML (.tx)
+type txTemplate struct {
+	Tileset *tmxTileset `xml:"tileset"`
+	Object  tmxObject   `xml:"object"`
+}
+
+// Formato JSON (.tj)
+type tjTemplate struct {
+	Tileset *struct {
+		FirstGID int    `json:"firstgid"`
+		Source   string `json:"source"`
+	} `json:"tileset"`
+	Object TiledObject `json:"object"`
+}
+
+func loadTemplate(path string) (*objectTemplate, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler o template %s: %w", path, err)
+	}
+
+	tpl := &objectTemplate{}
+	var tilesetSource string
+	if strings.ToLower(filepath.Ext(path)) == ".tx" {
+		var data txTemplate
+		if err := xml.Unmarshal(contents, &data); err != nil {
+			return nil, fmt.Errorf("falha ao decodificar o template %s: %w", path, err)
+		}
+		if tpl.Object, err = data.Object.convert(); err != nil {
+			return nil, fmt.Errorf("template %s: %w", path, err)
+		}
+		if data.Tileset != nil {
+			tilesetSource, tpl.TilesetFirstGID = data.Tileset.Source, data.Tileset.FirstGID
+		}
+	} else {
+		var data tjTemplate
+		if err := json.Unmarshal(contents, &data); err != nil {
+			return nil, fmt.Errorf("falha ao decodificar o template %s: %w", path, err)
+		}
+		tpl.Object = data.Object
+		if data.Tileset != nil {
+			tilesetSource, tpl.TilesetFirstGID = data.Tileset.Source, data.Tileset.FirstGID
+		}
+	}
+	if tilesetSource != "" {
+		tpl.TilesetSource = filepath.Join(filepath.Dir(path), tilesetSource)
+	}
+	return tpl, nil
+}
+
+// resolveTemplates completa os objetos que usam um template com o que
+// eles não definem. Cada template é lido uma vez só.
+func resolveTemplates(m *TilemapJSON) error {
+	mapDir := filepath.Dir(m.path)
+	loaded := make(map[string]*objectTemplate)
+
+	for i := range m.Layers {
+		for j := range m.Layers[i].Objects {
+			obj := &m.Layers[i].Objects[j]
+			if obj.Template == "" {
+				continue
+			}
+			path := filepath.Join(mapDir, obj.Template)
+			tpl, ok := loaded[path]
+			if !ok {
+				var err error
+				if tpl, err = loadTemplate(path); err != nil {
+					return fmt.Errorf("objeto %d: %w", obj.ID, err)
+				}
+				loaded[path] = tpl
+			}
+			if err := tpl.apply(obj, m); err != nil {
+				return fmt.Errorf("objeto %d: %w", obj.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// apply preenche obj com os campos do template. O que o objeto define
+// ganha; as propriedades se somam, com as do objeto por cima.
+func (t *objectTemplate) apply(obj *TiledObject, m *TilemapJSON) error {
+	base := t.Object
+	if obj.Name == "" {
+		obj.Name = base.Name
+	}
+	if obj.Type == "" {
+		obj.Type = base.Type
+	}
+	if obj.Width == 0 {
+		obj.Width = base.Width
+	}
+	if obj.Height == 0 {
+		obj.Height = base.Height
+	}
+	if obj.Polyline == nil {
+		obj.Polyline = base.Polyline
+	}
+	if obj.Polygon == nil {
+		obj.Polygon = base.Polygon
+	}
+
+	// O GID do template é do tileset do template; no mapa o mesmo tileset
+	// pode ter outro firstgid. Os bits de espelhamento continuam.
+	if obj.GID == 0 && base.GID != 0 {
+		firstGID, ok := m.firstGID(t.TilesetSource)
+		if !ok {
+			return fmt.Errorf("o tileset %s do template não está no mapa", t.TilesetSource)
+		}
+		tile := DecodeGID(base.GID)
+		tile.ID += firstGID - t.TilesetFirstGID
+		obj.GID = tile.Encode()
+	}
+
+	properties := append([]TiledProperty(nil), base.Properties...)
+	for _, prop := range obj.Properties {
+		replaced := false
+		for i := range properties {
+			if properties[i].Name == prop.Name {
+				properties[i] = prop
+				replaced = true
+			}
+		}
+		if !replaced {
+			properties = append(properties, prop)
+		}
+	}
+	obj.Properties = properties
+	return nil
+}
+
+// firstGID acha o firstgid de um tileset do mapa pelo caminho do arquivo
+// (relativo ao projeto).
+func (t *TilemapJSON) firstGID(source string) (int, bool) {
+	mapDir := filepath.Dir(t.path)
+	for _, tilesetData := range t.Tilesets {
+		path, _ := tilesetData["source"].(string)
+		firstGID, _ := tilesetData["firstgid"].(float64)
+		if path != "" && filepath.Join(mapDir, path) == filepath.Clean(source) {
+			return int(firstGID), true
+		}
+	}
+	return 0, false
+}
@@ -2,10 +2,13 @@ package tilemap
 
 import (
 	"encoding/json"
+	"fmt"
+	"image"
 	"log"
 	"os"
-	"path"
+	"path/filepath"
 	"rpg-go/tileset"
+	"strings"
 )
 
 // data we want for one layer in our list of layers
@@ -17,6 +20,10 @@ type TilemapLayerJSON struct {
 	Type       string        `json:"type"` // "tilelayer" ou "objectgroup"
 	Objects    []TiledObject `json:"objects"`
 	Collisions []TiledObject `json:"collisions"`
+	// Célula (em tiles) onde Data começa. Só camadas de mapas infinitos
+	// saem de (0, 0), às vezes para coordenadas negativas.
+	StartX int `json:"startx"`
+	StartY int `json:"starty"`
 }
 
 type TiledProperty struct {
@@ -25,6 +32,11 @@ type TiledProperty struct {
 	Value any    `json:"value"`
 }
 
+type TiledPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
 type TiledObject struct {
 	ID         int             `json:"id"`
 	Name       string          `json:"name"`
@@ -35,13 +47,79 @@ type TiledObject struct {
 	Height     float64         `json:"height"`
 	GID        int             `json:"gid,omitempty"`
 	Properties []TiledProperty `json:"properties"`
+	// Template (.tx ou .tj, relativo ao mapa) de onde o objeto herda o que
+	// não define. Já vem resolvido por Load.
+	Template string `json:"template,omitempty"`
+	// Pontos relativos a (X, Y), para objetos do tipo polilinha/polígono
+	Polyline []TiledPoint `json:"polyline,omitempty"`
+	Polygon  []TiledPoint `json:"polygon,omitempty"`
 }
 
 // all layers in a tilemap
 type TilemapJSON struct {
 	Layers []TilemapLayerJSON `json:"layers"`
+	// Propriedades do mapa (ex: "music")
+	Properties []TiledProperty `json:"properties"`
 	// raw data for each tileset (path, gid)
 	Tilesets []map[string]any `json:"tilesets"`
+
+	// arquivo de onde o mapa foi lido; os caminhos do mapa são relativos a ele
+	path string
+}
+
+// Bounds é a área coberta pelas camadas de tiles, em tiles.
+func (t *TilemapJSON) Bounds() image.Rectangle {
+	var bounds image.Rectangle
+	for _, layer := range t.Layers {
+		if layer.Type == "tilelayer" {
+			bounds = bounds.Union(image.Rect(layer.StartX, layer.StartY, layer.StartX+layer.Width, layer.StartY+layer.Height))
+		}
+	}
+	return bounds
+}
+
+// tilesetPath converte o caminho de um tileset, relativo ao mapa, para um
+// relativo ao projeto.
+func (t *TilemapJSON) tilesetPath(tilesetData map[string]any) string {
+	mapDir := "assets/maps/"
+	if t.path != "" {
+		mapDir = filepath.Dir(t.path)
+	}
+	return filepath.Join(mapDir, tilesetData["source"].(string))
+}
+
+// TileCollision são os colisores desenhados num tile no editor de
+// colisões do Tiled, relativos ao canto da imagem do tile.
+type TileCollision struct {
+	Width, Height int // tamanho da imagem do tile
+	Shapes        []image.Rectangle
+}
+
+// TileCollisions lê dos tilesets os colisores de cada tile, pelo GID. Não
+// carrega imagens, então serve também sem janela.
+func (t *TilemapJSON) TileCollisions() (map[int]TileCollision, error) {
+	collisions := make(map[int]TileCollision)
+	for _, tilesetData := range t.Tilesets {
+		data, err := tileset.Load(t.tilesetPath(tilesetData))
+		if err != nil {
+			return nil, err
+		}
+		firstGID := int(tilesetData["firstgid"].(float64))
+		for _, tile := range data.Tiles {
+			if tile.ObjectGroup == nil || len(tile.ObjectGroup.Objects) == 0 {
+				continue
+			}
+			collision := TileCollision{Width: data.TileWidth, Height: data.TileHeight}
+			if tile.ImageWidth > 0 {
+				collision.Width, collision.Height = tile.ImageWidth, tile.ImageHeight
+			}
+			for _, shape := range tile.ObjectGroup.Objects {
+				collision.Shapes = append(collision.Shapes, shape.Bounds())
+			}
+			collisions[firstGID+tile.ID] = collision
+		}
+	}
+	return collisions, nil
 }
 
 // temp function to generate all of our tilesets and return a slice of them
@@ -49,9 +127,7 @@ func (t *TilemapJSON) GenTilesets() ([]*tileset.Tileset, error) {
 	tilesets := make([]*tileset.Tileset, 0)
 
 	for _, tilesetData := range t.Tilesets {
-		// convert map relative path to project relative path
-		tilesetPath := path.Join("assets/maps/", tilesetData["source"].(string))
-		tileset, err := tileset.NewTileset(tilesetPath, int(tilesetData["firstgid"].(float64)))
+		tileset, err := tileset.NewTileset(t.tilesetPath(tilesetData), int(tilesetData["firstgid"].(float64)))
 		if err != nil {
 			return nil, err
 		}
@@ -65,20 +141,82 @@ func (t *TilemapJSON) GenTilesets() ([]*tileset.Tileset, error) {
 func GetIntProperty(name string, properties []TiledProperty) (int, bool) {
 	for _, prop := range properties {
 		if prop.Name == name {
-			// O Tiled exporta números como float64, então precisamos converter.
-			if value, ok := prop.Value.(float64); ok {
+			// Aceita propriedades "int" e "float": um float é truncado.
+			if value, ok := propertyNumber(prop.Value); ok {
 				return int(value), true
 			}
-			log.Printf("Aviso: Propriedade '%s' encontrada, mas não é um número (float64).", name)
+			log.Printf("Aviso: Propriedade '%s' encontrada, mas não é um número.", name)
 			return 0, false
 		}
 	}
 	return 0, false // Retorna o valor padrão 0 se a propriedade não for encontrada.
 }
 
+func GetFloatProperty(name string, properties []TiledProperty) (float64, bool) {
+	for _, prop := range properties {
+		if prop.Name == name {
+			if value, ok := propertyNumber(prop.Value); ok {
+				return value, true
+			}
+			log.Printf("Aviso: Propriedade '%s' encontrada, mas não é um número.", name)
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// propertyNumber lê um valor numérico. O Tiled exporta "int" e "float" como
+// float64, mas propriedades criadas no código podem vir como int.
+func propertyNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func GetBoolProperty(name string, properties []TiledProperty) (bool, bool) {
+	for _, prop := range properties {
+		if prop.Name == name {
+			if value, ok := prop.Value.(bool); ok {
+				return value, true
+			}
+			log.Printf("Aviso: Propriedade '%s' encontrada, mas não é um bool.", name)
+			return false, false
+		}
+	}
+	return false, false
+}
+
+func GetStringProperty(name string, properties []TiledProperty) (string, bool) {
+	for _, prop := range properties {
+		if prop.Name == name {
+			if value, ok := prop.Value.(string); ok {
+				return value, true
+			}
+			log.Printf("Aviso: Propriedade '%s' encontrada, mas não é uma string.", name)
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// Load lê um mapa do Tiled, em XML (.tmx) ou exportado em JSON (.json,
+// .tmj), e resolve os templates dos objetos.
+func Load(path string) (*TilemapJSON, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tmx":
+		return NewTilemapTMX(path)
+	default:
+		return NewTilemapJSON(path)
+	}
+}
+
 // opens the file, parses it, and returns the json object + potential error
-func NewTilemapJSON(filepath string) (*TilemapJSON, error) {
-	contents, err := os.ReadFile(filepath)
+func NewTilemapJSON(path string) (*TilemapJSON, error) {
+	contents, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -89,5 +227,9 @@ func NewTilemapJSON(filepath string) (*TilemapJSON, error) {
 		return nil, err
 	}
 
+	tilemapJSON.path = path
+	if err := resolveTemplates(&tilemapJSON); err != nil {
+		return nil, fmt.Errorf("mapa %s: %w", path, err)
+	}
 	return &tilemapJSON, nil
 }
@@ -0,0 +1,289 @@
+package tilemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Formato XML do Tiled (.tmx, .tx). Tudo é convertido para as mesmas
+// structs dos mapas exportados em JSON, então o resto do jogo não sabe de
+// onde o mapa veio.
+
+type tmxMap struct {
+	Properties []tmxProperty `xml:"properties>property"`
+	Tilesets   []tmxTileset  `xml:"tileset"`
+	// Camadas na ordem do arquivo: <layer>, <objectgroup>, <imagelayer>...
+	Layers []tmxLayer `xml:",any"`
+}
+
+type tmxTileset struct {
+	FirstGID int    `xml:"firstgid,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+type tmxLayer struct {
+	XMLName xml.Name
+	Name    string      `xml:"name,attr"`
+	Width   int         `xml:"width,attr"`
+	Height  int         `xml:"height,attr"`
+	Data    tmxData     `xml:"data"`
+	Objects []tmxObject `xml:"object"`
+}
+
+type tmxData struct {
+	Encoding    string     `xml:"encoding,attr"`
+	Compression string     `xml:"compression,attr"`
+	Tiles       []tmxTile  `xml:"tile"` // sem encoding, um <tile> por célula
+	Text        string     `xml:",chardata"`
+	Chunks      []tmxChunk `xml:"chunk"` // só em mapas infinitos
+}
+
+type tmxChunk struct {
+	X      int       `xml:"x,attr"`
+	Y      int       `xml:"y,attr"`
+	Width  int       `xml:"width,attr"`
+	Height int       `xml:"height,attr"`
+	Tiles  []tmxTile `xml:"tile"`
+	Text   string    `xml:",chardata"`
+}
+
+type tmxTile struct {
+	GID uint32 `xml:"gid,attr"`
+}
+
+type tmxObject struct {
+	ID       int     `xml:"id,attr"`
+	Name     string  `xml:"name,attr"`
+	Type     string  `xml:"type,attr"`
+	Class    string  `xml:"class,attr"` // nome de "type" no Tiled 1.9
+	X        float64 `xml:"x,attr"`
+	Y        float64 `xml:"y,attr"`
+	Width    float64 `xml:"width,attr"`
+	Height   float64 `xml:"height,attr"`
+	GID      uint32  `xml:"gid,attr"`
+	Template string  `xml:"template,attr"`
+
+	Properties []tmxProperty `xml:"properties>property"`
+	Polyline   *tmxPoints    `xml:"polyline"`
+	Polygon    *tmxPoints    `xml:"polygon"`
+}
+
+type tmxPoints struct {
+	Points string `xml:"points,attr"`
+}
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"` // strings de várias linhas vêm no corpo
+	// Propriedades de uma propriedade do tipo "class"
+	Properties []tmxProperty `xml:"properties>property"`
+}
+
+// NewTilemapTMX lê um mapa salvo pelo Tiled em XML.
+func NewTilemapTMX(path string) (*TilemapJSON, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var data tmxMap
+	if err := xml.Unmarshal(contents, &data); err != nil {
+		return nil, fmt.Errorf("falha ao decodificar o mapa %s: %w", path, err)
+	}
+
+	m, err := data.convert()
+	if err != nil {
+		return nil, fmt.Errorf("mapa %s: %w", path, err)
+	}
+	m.path = path
+	if err := resolveTemplates(m); err != nil {
+		return nil, fmt.Errorf("mapa %s: %w", path, err)
+	}
+	return m, nil
+}
+
+func (data tmxMap) convert() (*TilemapJSON, error) {
+	m := &TilemapJSON{}
+
+	var err error
+	if m.Properties, err = convertProperties(data.Properties); err != nil {
+		return nil, err
+	}
+
+	for _, ts := range data.Tilesets {
+		if ts.Source == "" {
+			return nil, fmt.Errorf("tileset embutido no mapa (firstgid %d): exporte-o para um arquivo .tsx", ts.FirstGID)
+		}
+		// Mesmo formato do JSON, onde os números vêm como float64
+		m.Tilesets = append(m.Tilesets, map[string]any{
+			"firstgid": float64(ts.FirstGID),
+			"source":   ts.Source,
+		})
+	}
+
+	for _, l := range data.Layers {
+		layer := TilemapLayerJSON{Name: l.Name, Width: l.Width, Height: l.Height}
+		switch l.XMLName.Local {
+		case "layer":
+			layer.Type = "tilelayer"
+			if err := l.Data.decodeInto(&layer); err != nil {
+				return nil, fmt.Errorf("camada %q: %w", l.Name, err)
+			}
+		case "objectgroup":
+			layer.Type = "objectgroup"
+			for _, o := range l.Objects {
+				obj, err := o.convert()
+				if err != nil {
+					return nil, fmt.Errorf("camada %q: objeto %d: %w", l.Name, o.ID, err)
+				}
+				layer.Objects = append(layer.Objects, obj)
+			}
+		case "imagelayer":
+			layer.Type = "imagelayer"
+		default:
+			// <editorsettings>, <group>... não viram camadas
+			continue
+		}
+		m.Layers = append(m.Layers, layer)
+	}
+	return m, nil
+}
+
+// decodeInto lê os GIDs de uma camada de tiles, juntando os pedaços dos
+// mapas infinitos.
+func (d tmxData) decodeInto(layer *TilemapLayerJSON) error {
+	if len(d.Chunks) == 0 {
+		data, err := d.decode(d.Text, d.Tiles)
+		layer.Data = data
+		return err
+	}
+	chunks := make([]chunk, len(d.Chunks))
+	for i, c := range d.Chunks {
+		data, err := d.decode(c.Text, c.Tiles)
+		if err != nil {
+			return fmt.Errorf("pedaço (%d, %d): %w", c.X, c.Y, err)
+		}
+		chunks[i] = chunk{X: c.X, Y: c.Y, Width: c.Width, Height: c.Height, Data: data}
+	}
+	return layer.mergeChunks(chunks)
+}
+
+// decode lê os GIDs de um <data> ou <chunk> conforme a codificação.
+func (d tmxData) decode(text string, tiles []tmxTile) ([]int, error) {
+	switch d.Encoding {
+	case "":
+		data := make([]int, len(tiles))
+		for i, tile := range tiles {
+			data[i] = int(tile.GID)
+		}
+		return data, nil
+	case "csv":
+		return decodeCSV(text)
+	case "base64":
+		return decodeBase64(text, d.Compression)
+	default:
+		return nil, fmt.Errorf("codificação %q não suportada", d.Encoding)
+	}
+}
+
+func (o tmxObject) convert() (TiledObject, error) {
+	obj := TiledObject{
+		ID:       o.ID,
+		Name:     o.Name,
+		Type:     o.Type,
+		X:        o.X,
+		Y:        o.Y,
+		Width:    o.Width,
+		Height:   o.Height,
+		GID:      int(o.GID),
+		Template: o.Template,
+	}
+	if obj.Type == "" {
+		obj.Type = o.Class
+	}
+
+	var err error
+	if obj.Properties, err = convertProperties(o.Properties); err != nil {
+		return obj, err
+	}
+	if o.Polyline != nil {
+		if obj.Polyline, err = parsePoints(o.Polyline.Points); err != nil {
+			return obj, err
+		}
+	}
+	if o.Polygon != nil {
+		if obj.Polygon, err = parsePoints(o.Polygon.Points); err != nil {
+			return obj, err
+		}
+	}
+	return obj, nil
+}
+
+// parsePoints lê os pontos de uma polilinha/polígono: "x1,y1 x2,y2 ...".
+func parsePoints(points string) ([]TiledPoint, error) {
+	var result []TiledPoint
+	for _, pair := range strings.Fields(points) {
+		xs, ys, ok := strings.Cut(pair, ",")
+		x, errX := strconv.ParseFloat(xs, 64)
+		y, errY := strconv.ParseFloat(ys, 64)
+		if !ok || errX != nil || errY != nil {
+			return nil, fmt.Errorf("ponto inválido %q", pair)
+		}
+		result = append(result, TiledPoint{X: x, Y: y})
+	}
+	return result, nil
+}
+
+func convertProperties(props []tmxProperty) ([]TiledProperty, error) {
+	var result []TiledProperty
+	for _, p := range props {
+		prop, err := p.convert()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, prop)
+	}
+	return result, nil
+}
+
+// convert dá ao valor o mesmo tipo que ele teria no JSON: números (e ids
+// de objeto) como float64, bool como bool e o resto como string.
+func (p tmxProperty) convert() (TiledProperty, error) {
+	prop := TiledProperty{Name: p.Name, Type: p.Type}
+	if prop.Type == "" {
+		prop.Type = "string"
+	}
+	value := p.Value
+	if value == "" {
+		value = p.Text
+	}
+
+	switch prop.Type {
+	case "int", "float", "object":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return prop, fmt.Errorf("propriedade %q: número inválido %q", p.Name, value)
+		}
+		prop.Value = number
+	case "bool":
+		prop.Value = value == "true"
+	case "class":
+		members, err := convertProperties(p.Properties)
+		if err != nil {
+			return prop, fmt.Errorf("propriedade %q: %w", p.Name, err)
+		}
+		fields := make(map[string]any, len(members))
+		for _, member := range members {
+			fields[member.Name] = member.Value
+		}
+		prop.Value = fields
+	default:
+		prop.Value = value
+	}
+	return prop, nil
+}
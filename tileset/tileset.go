@@ -8,12 +8,14 @@ import (
 	"os"
 	"path/filepath"
 	"rpg-go/constants"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 // TilesetJSON espelha a estrutura de um arquivo .tsx exportado como .json
+// (os .tsx são lidos para esta mesma struct)
 type TilesetJSON struct {
 	Image       string     `json:"image"`       // Usado por tilesets baseados em uma única imagem (spritesheet)
 	Columns     int        `json:"columns"`     // Número de colunas no spritesheet. ESSENCIAL!
@@ -24,8 +26,64 @@ type TilesetJSON struct {
 
 // TileJSON representa um único tile dentro de uma coleção de imagens.
 type TileJSON struct {
-	ID    int    `json:"id"`
-	Image string `json:"image"`
+	ID          int    `json:"id"`
+	Image       string `json:"image"`
+	ImageWidth  int    `json:"imagewidth"`
+	ImageHeight int    `json:"imageheight"`
+	// Colisores desenhados no tile (editor de colisões do Tiled); também
+	// aparece em tiles de spritesheet
+	ObjectGroup *ObjectGroupJSON `json:"objectgroup,omitempty"`
+}
+
+type ObjectGroupJSON struct {
+	Objects []ShapeJSON `json:"objects"`
+}
+
+// ShapeJSON é um colisor de tile, relativo ao canto do tile.
+type ShapeJSON struct {
+	X       float64     `json:"x"`
+	Y       float64     `json:"y"`
+	Width   float64     `json:"width"`
+	Height  float64     `json:"height"`
+	Polygon []PointJSON `json:"polygon,omitempty"` // relativo a (X, Y)
+}
+
+type PointJSON struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Bounds é o retângulo do colisor (polígonos viram o retângulo que os cobre).
+func (s ShapeJSON) Bounds() image.Rectangle {
+	if len(s.Polygon) == 0 {
+		return image.Rect(int(s.X), int(s.Y), int(s.X+s.Width), int(s.Y+s.Height))
+	}
+	minX, minY := s.Polygon[0].X, s.Polygon[0].Y
+	maxX, maxY := minX, minY
+	for _, p := range s.Polygon[1:] {
+		minX, minY = min(minX, p.X), min(minY, p.Y)
+		maxX, maxY = max(maxX, p.X), max(maxY, p.Y)
+	}
+	return image.Rect(int(s.X+minX), int(s.Y+minY), int(s.X+maxX), int(s.Y+maxY))
+}
+
+// Load lê um arquivo de tileset (.json, .tsj ou .tsx) sem carregar as
+// imagens.
+func Load(path string) (TilesetJSON, error) {
+	var data TilesetJSON
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return data, fmt.Errorf("falha ao ler o arquivo do tileset %s: %w", path, err)
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".tsx" {
+		if data, err = decodeTSX(contents); err != nil {
+			return data, fmt.Errorf("falha ao decodificar o tileset %s: %w", path, err)
+		}
+	} else if err := json.Unmarshal(contents, &data); err != nil {
+		return data, fmt.Errorf("falha ao decodificar o JSON do tileset %s: %w", path, err)
+	}
+	return data, nil
 }
 
 // Tileset é a nossa estrutura unificada. Ela pode representar tanto um
@@ -44,14 +102,9 @@ type Tileset struct {
 // NewTileset é a nossa factory. Ela lê um arquivo de tileset do Tiled,
 // determina seu tipo, e retorna uma struct Tileset pronta para uso.
 func NewTileset(path string, firstGid int) (*Tileset, error) {
-	contents, err := os.ReadFile(path)
+	data, err := Load(path)
 	if err != nil {
-		return nil, fmt.Errorf("falha ao ler o arquivo do tileset %s: %w", path, err)
-	}
-
-	var data TilesetJSON
-	if err := json.Unmarshal(contents, &data); err != nil {
-		return nil, fmt.Errorf("falha ao decodificar o JSON do tileset %s: %w", path, err)
+		return nil, err
 	}
 
 	tileset := &Tileset{
@@ -0,0 +1,88 @@
+package tileset
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Formato XML (.tsx) de um tileset do Tiled
+type tsxTileset struct {
+	TileWidth  int      `xml:"tilewidth,attr"`
+	TileHeight int      `xml:"tileheight,attr"`
+	Columns    int      `xml:"columns,attr"`
+	Image      tsxImage `xml:"image"`
+	Tiles      []struct {
+		ID      int         `xml:"id,attr"`
+		Image   tsxImage    `xml:"image"`
+		Objects []tsxObject `xml:"objectgroup>object"`
+	} `xml:"tile"`
+}
+
+type tsxImage struct {
+	Source string `xml:"source,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+}
+
+type tsxObject struct {
+	X       float64 `xml:"x,attr"`
+	Y       float64 `xml:"y,attr"`
+	Width   float64 `xml:"width,attr"`
+	Height  float64 `xml:"height,attr"`
+	Polygon *struct {
+		Points string `xml:"points,attr"`
+	} `xml:"polygon"`
+}
+
+// decodeTSX converte um .tsx para a mesma struct dos tilesets em JSON.
+func decodeTSX(contents []byte) (TilesetJSON, error) {
+	var tsx tsxTileset
+	if err := xml.Unmarshal(contents, &tsx); err != nil {
+		return TilesetJSON{}, fmt.Errorf("XML inválido: %w", err)
+	}
+
+	data := TilesetJSON{
+		Image:      tsx.Image.Source,
+		Columns:    tsx.Columns,
+		TileWidth:  tsx.TileWidth,
+		TileHeight: tsx.TileHeight,
+	}
+	for _, tile := range tsx.Tiles {
+		// Num spritesheet os <tile> só trazem colisores (e animações)
+		if tile.Image.Source == "" && len(tile.Objects) == 0 {
+			continue
+		}
+		t := TileJSON{ID: tile.ID, Image: tile.Image.Source, ImageWidth: tile.Image.Width, ImageHeight: tile.Image.Height}
+		if len(tile.Objects) > 0 {
+			t.ObjectGroup = &ObjectGroupJSON{}
+			for _, o := range tile.Objects {
+				shape, err := o.convert()
+				if err != nil {
+					return data, fmt.Errorf("tile %d: %w", tile.ID, err)
+				}
+				t.ObjectGroup.Objects = append(t.ObjectGroup.Objects, shape)
+			}
+		}
+		data.Tiles = append(data.Tiles, t)
+	}
+	return data, nil
+}
+
+func (o tsxObject) convert() (ShapeJSON, error) {
+	shape := ShapeJSON{X: o.X, Y: o.Y, Width: o.Width, Height: o.Height}
+	if o.Polygon == nil {
+		return shape, nil
+	}
+	for _, pair := range strings.Fields(o.Polygon.Points) {
+		xs, ys, ok := strings.Cut(pair, ",")
+		x, errX := strconv.ParseFloat(xs, 64)
+		y, errY := strconv.ParseFloat(ys, 64)
+		if !ok || errX != nil || errY != nil {
+			return shape, fmt.Errorf("ponto inválido %q", pair)
+		}
+		shape.Polygon = append(shape.Polygon, PointJSON{X: x, Y: y})
+	}
+	return shape, nil
+}